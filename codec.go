@@ -52,6 +52,68 @@ func decode(uri []byte) []byte {
 	return iri
 }
 
+// Encode converts IRIs to URIs as defined by RFC 3987. Non-ASCII characters
+// are encoded as UTF-8 and percent-encoded, reserved characters and valid
+// percent-encoded sequences are preserved. Characters not allowed by URI
+// (e.g. space, controls and malformed percent) are percent-encoded so that
+// the result is strictly ASCII URI.
+// https://www.rfc-editor.org/rfc/rfc3987#section-3.1
+//
+// Encode is counterpart of Decode, for any uri
+//
+//	Decode(Encode(Decode(uri))) == Decode(uri)
+func Encode(iri string) string {
+	return string(encode([]byte(iri)))
+}
+
+func encode(iri []byte) []byte {
+	n := 0
+	for i := 0; i < len(iri); i++ {
+		if iri[i] == '%' && i+2 < len(iri) && ishex(iri[i+1]) && ishex(iri[i+2]) {
+			i += 2
+			continue
+		}
+		if shouldEscape(iri[i]) {
+			n++
+		}
+	}
+
+	if n == 0 {
+		return iri
+	}
+
+	uri := make([]byte, 0, len(iri)+2*n)
+	for i := 0; i < len(iri); i++ {
+		c := iri[i]
+		switch {
+		case c == '%' && i+2 < len(iri) && ishex(iri[i+1]) && ishex(iri[i+2]):
+			uri = append(uri, iri[i:i+3]...)
+			i += 2
+		case shouldEscape(c):
+			uri = append(uri, '%', upperhex[c>>4], upperhex[c&15])
+		default:
+			uri = append(uri, c)
+		}
+	}
+
+	return uri
+}
+
+const upperhex = "0123456789ABCDEF"
+
+// characters allowed in URI are unreserved and reserved ones
+func shouldEscape(c byte) bool {
+	switch {
+	case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		return false
+	case c == '-' || c == '.' || c == '_' || c == '~':
+		return false
+	case checkReserved(c):
+		return false
+	}
+	return true
+}
+
 func checkReserved(b byte) bool {
 	return b == ':' ||
 		b == '/' || b == '?' || b == '#' ||
@@ -48,3 +48,43 @@ func TestUnHex(t *testing.T) {
 		)
 	}
 }
+
+func TestEncode(t *testing.T) {
+	for iri, uri := range map[string]string{
+		"wiki":               "wiki",
+		"Ῥόδος":              "%E1%BF%AC%CF%8C%CE%B4%CE%BF%CF%82",
+		"Ῥόδος/a?b=Ῥ#Ῥ":      "%E1%BF%AC%CF%8C%CE%B4%CE%BF%CF%82/a?b=%E1%BF%AC#%E1%BF%AC",
+		"%1F%e1":             "%1F%e1",
+		"-._~":               "-._~",
+		":/?#[]@!$&'()*+,;=": ":/?#[]@!$&'()*+,;=",
+		"a b<>\"{}|\\^`":     "a%20b%3C%3E%22%7B%7D%7C%5C%5E%60",
+		"\x00\x7f":           "%00%7F",
+		"%%%":                "%25%25%25",
+		"%Ww%wW%%":           "%25Ww%25wW%25%25",
+	} {
+		it.Then(t).Should(
+			it.Equal(Encode(iri), uri),
+		)
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	for _, uri := range []string{
+		"wiki",
+		"%E1%BF%AC%CF%8C%CE%B4%CE%BF%CF%82",
+		"%D0%9A%D0%BE%D0%BC%D0%BF%D0%B0%D0%BA%D1%82%D0%BD%D1%8B%D0%B5%20%D1%83%D0%BD%D0%B8",
+		"%00%01%02%03%04%05%06%07%08%09%0A%0B%0C%0D%0E%0F%10%11%12%13%14%15%16%17%18%19%1A%1B%1C%1D%1E%1F",
+		"%2D%2E%5F%7E",
+		":/?#[]@!$&'()*+,;=",
+		"%3A%2F%3F%23%5B%5D%40%21%24%26%27%28%29%2A%2B%2C%3B%3D",
+		"%%%",
+		"%Ww%wW%%",
+		"%25",
+		"a%20b",
+	} {
+		iri := Decode(uri)
+		it.Then(t).Should(
+			it.Equal(Decode(Encode(iri)), iri),
+		)
+	}
+}
@@ -15,7 +15,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
-	"path/filepath"
+	"path"
 	"strings"
 
 	"github.com/fogfish/curie/v2/internal/reference"
@@ -94,28 +94,136 @@ type Namespace string
 // Create new CURIE from schema and reference
 func (schema Namespace) IRI(ref string) IRI { return New(string(schema), ref) }
 
+const (
+	// DefaultPrefix is the prefix used when it is omitted from CURIE.
+	//
+	//	curie.Namespaces{curie.DefaultPrefix: "https://example.com/"}
+	//	CURIE ⟼ https://example.com/CURIE
+	DefaultPrefix = ""
+
+	// BlankNode is the prefix of blank node identifiers (e.g. `_:b0`).
+	// Blank nodes are never expanded and never produced by compaction,
+	// the prefix is not a default one.
+	BlankNode = "_"
+)
+
 // Prefixes is a collection of prefixes defined by the application
 type Prefixes interface {
 	Create(string) IRI
 	Lookup(string) (string, bool)
 }
 
+// Enumerable is optional interface of Prefixes, it iterates over the collection.
+// The iteration stops if f returns false.
+type Enumerable interface {
+	Range(f func(prefix, iri string) bool)
+}
+
+// collects enumerable prefixes into the map
+func namespacesOf(prefixes Prefixes) (Namespaces, bool) {
+	if ns, ok := prefixes.(Namespaces); ok {
+		return ns, true
+	}
+
+	seq, ok := prefixes.(Enumerable)
+	if !ok {
+		return nil, false
+	}
+
+	ns := Namespaces{}
+	seq.Range(func(prefix, iri string) bool {
+		ns[prefix] = iri
+		return true
+	})
+	return ns, true
+}
+
 // Namespaces is constant in-memory collection of prefixes defined by the application
+//
+// The compaction uses the longest matching expansion. Prefixes sharing the same
+// expansion are resolved deterministically, the shortest prefix wins, then
+// lexicographically smallest one. Use Trie for large collections.
+//
+// The DefaultPrefix declares expansion of CURIEs without prefix, the compaction
+// produces bare references for it.
+//
+// The expansion might be URI template (RFC 6570) with simple {var}, reserved
+// {+var} and fragment {#var} expressions. Segments of CURIE reference are
+// assigned to variables in order, the last variable takes the rest of reference.
+//
+//	curie.Namespaces{"gh": "https://github.com/{owner}/{repo}"}
+//	gh:fogfish/curie ⟼ https://github.com/fogfish/curie
+//
+// The prefix with invalid URI template is not usable, URL fails with
+// ErrInvalidTemplate and the compaction never produces it. Use Lint or
+// NewNamespaces to detect it.
 type Namespaces map[string]string
 
 // Create new URI using prefix table
 func (ns Namespaces) Create(uri string) IRI {
+	var templates []prefixTemplate
+
+	prefix, n := "", -1
+	for key, val := range ns {
+		if key == BlankNode {
+			continue
+		}
+
+		if isTemplate(val) {
+			if t, err := parseTemplate(val); err == nil {
+				templates = append(templates, prefixTemplate{key, t})
+			}
+			continue
+		}
+
+		if !strings.HasPrefix(uri, val) {
+			continue
+		}
+
+		if len(val) > n || (len(val) == n && preferred(key, prefix)) {
+			prefix, n = key, len(val)
+		}
+	}
+
+	if iri, has := matchTemplates(templates, uri, prefix, n); has {
+		return iri
+	}
+
+	if n == -1 {
+		return IRI(uri)
+	}
+
+	return compact(prefix, uri[n:])
+}
+
+// compact URI suffix to CURIE
+func compact(prefix, suffix string) IRI {
 	// Note: All non-ASCII code points in the IRI should next be encoded as UTF-8
 	// https://en.wikipedia.org/wiki/Internationalized_Resource_Identifier
 	// https://www.ietf.org/rfc/rfc3987.html#section-5.3.2.3
-	for key, val := range ns {
-		if strings.HasPrefix(uri, val) {
-			ref := Decode(uri[len(val):])
-			return IRI(key + ":" + string(ref))
+	return compactRef(prefix, Decode(suffix))
+}
+
+// compact reference to CURIE
+func compactRef(prefix, ref string) IRI {
+	// default prefix is omitted unless the reference is ambiguous
+	if prefix == DefaultPrefix {
+		seg := ref[:strings.IndexAny(ref+"/", "/?#")]
+		if len(ref) != 0 && strings.IndexByte(seg, ':') == -1 {
+			return IRI(ref)
 		}
 	}
 
-	return IRI(uri)
+	return IRI(prefix + ":" + ref)
+}
+
+// preferred is tie-breaking rule for prefixes sharing the same expansion,
+// the shortest prefix wins, then lexicographically smallest one.
+func preferred(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
 }
 
 // Lookup prefix in the map
@@ -124,7 +232,16 @@ func (ns Namespaces) Lookup(prefix string) (string, bool) {
 	return val, exists
 }
 
-// URI converts CURIE to fully qualified URL
+// Range iterates over prefixes
+func (ns Namespaces) Range(f func(prefix, iri string) bool) {
+	for prefix, iri := range ns {
+		if !f(prefix, iri) {
+			return
+		}
+	}
+}
+
+// URI converts CURIE to fully qualified URL, the result is strictly ASCII URI.
 //
 //	wikipedia:CURIE ⟼ http://en.wikipedia.org/wiki/CURIE
 func URI(prefixes Prefixes, iri IRI) string {
@@ -139,11 +256,47 @@ func URI(prefixes Prefixes, iri IRI) string {
 // URI converts fully qualified URL to CURIE
 //
 //	http://en.wikipedia.org/wiki/CURIE ⟼ wikipedia:CURIE
+//
+// The compaction is round-trip safe, the URI is returned as is if CURIE
+// cannot be expanded back to it or it is ambiguous: the reference starts
+// with `//` or the prefix is well-known URI scheme (e.g. http).
+// It guarantees
+//
+//	URI(prefixes, FromURI(prefixes, uri)) == uri
+//
+// for every ASCII URI (e.g. produced by URI), other inputs are compared in
+// the form encoded by URI. The exception is CURIE of declared prefix, it is
+// returned as is and expanded by URI (e.g. ex:y ⟼ ex:y ⟼ https://example.com/y).
 func FromURI(prefixes Prefixes, uri string) IRI {
-	return prefixes.Create(uri)
+	iri := prefixes.Create(uri)
+	if string(iri) == uri || isRoundTrip(prefixes, iri, uri) {
+		return iri
+	}
+
+	return IRI(uri)
+}
+
+// checks that CURIE is not ambiguous and it is expanded back to URI
+func isRoundTrip(prefixes Prefixes, iri IRI, uri string) bool {
+	schema, ref := Split(iri)
+	if strings.HasPrefix(ref, "//") {
+		return false
+	}
+
+	if _, has := schemes[strings.ToLower(schema)]; has {
+		return false
+	}
+
+	expect, err := url.Parse(Encode(uri))
+	if err != nil {
+		return false
+	}
+
+	return URI(prefixes, iri) == expect.String()
 }
 
-// URL converts CURIE to fully qualified url.URL type
+// URL converts CURIE to fully qualified url.URL type. The result is strictly
+// ASCII URI, non-ASCII characters are encoded as defined by RFC 3987, see Encode.
 //
 //	wikipedia:CURIE ⟼ http://en.wikipedia.org/wiki/CURIE
 func URL(prefixes Prefixes, iri IRI) (*url.URL, error) {
@@ -158,11 +311,21 @@ func URL(prefixes Prefixes, iri IRI) (*url.URL, error) {
 	// the default prefix value MUST be used.
 	//
 	uri := string(iri)
-	if prefix, exists := prefixes.Lookup(Schema(iri)); exists {
-		uri = prefix + Reference(iri)
+	schema, ref := Split(iri)
+	if schema != BlankNode {
+		if prefix, exists := prefixes.Lookup(schema); exists {
+			uri = prefix + ref
+			if isTemplate(prefix) {
+				t, err := templateOf(prefixes, schema, prefix)
+				if err != nil {
+					return nil, err
+				}
+				uri = t.expand(ref)
+			}
+		}
 	}
 
-	return url.Parse(uri)
+	return url.Parse(Encode(uri))
 }
 
 //------------------------------------------------------------------------------
@@ -229,7 +392,7 @@ func Base(iri IRI) string {
 		return ""
 	}
 
-	return filepath.Base(ref)
+	return path.Base(ref)
 }
 
 // Path returns all but the last element of CURIE reference
@@ -239,7 +402,7 @@ func Path(iri IRI) IRI {
 		return iri
 	}
 
-	ref = filepath.Dir(ref)
+	ref = path.Dir(ref)
 	if ref == "." {
 		ref = ""
 	}
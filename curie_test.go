@@ -200,6 +200,14 @@ func TestURL(t *testing.T) {
 		)
 	})
 
+	t.Run("ASCII", func(t *testing.T) {
+		v := curie.URI(prefixes, curie.IRI("wikipedia:Ῥόδος?q=Ῥόδος#Ῥόδος"))
+
+		it.Then(t).Should(
+			it.Equal(v, "http://en.wikipedia.org/wiki/%E1%BF%AC%CF%8C%CE%B4%CE%BF%CF%82?q=%E1%BF%AC%CF%8C%CE%B4%CE%BF%CF%82#%E1%BF%AC%CF%8C%CE%B4%CE%BF%CF%82"),
+		)
+	})
+
 	t.Run("Invalid URL", func(t *testing.T) {
 		v := curie.URI(prefixes, curie.IRI("%2f:first_path_segment_in_URL_cannot_contain_colon"))
 
@@ -395,3 +403,53 @@ func TestDecodeError(t *testing.T) {
 		it.Nil(err),
 	)
 }
+
+func TestFromURIRoundTrip(t *testing.T) {
+	prefixes := curie.Namespaces{
+		curie.DefaultPrefix: "https://example.org/",
+		"ex":                "https://example.com",
+		"wiki":              "http://en.wikipedia.org/wiki/",
+	}
+
+	for uri, expect := range map[string]curie.IRI{
+		"https://example.com/b":                  "ex:/b",
+		"https://example.com?q=1":                "ex:?q=1",
+		"https://example.com//x":                 "https://example.com//x",
+		"https://example.org/b":                  "b",
+		"https://example.org/":                   ":",
+		"https://example.org/?q=1":               "?q=1",
+		"https://example.org/a:b":                ":a:b",
+		"https://example.org/a%41":               "https://example.org/a%41",
+		"http://en.wikipedia.org/wiki/%E1%BF%AC": "wiki:Ῥ",
+		"http://en.wikipedia.org/wiki/a%2Fb":     "wiki:a%2Fb",
+		"https://example.net/a":                  "https://example.net/a",
+	} {
+		t.Run(uri, func(t *testing.T) {
+			iri := curie.FromURI(prefixes, uri)
+
+			it.Then(t).Should(
+				it.Equal(iri, expect),
+				it.Equal(curie.URI(prefixes, iri), uri),
+			)
+		})
+	}
+
+	t.Run("Scheme", func(t *testing.T) {
+		prefixes := curie.Namespaces{"http": "http://example.org/"}
+		iri := curie.FromURI(prefixes, "http://example.org/a")
+
+		it.Then(t).Should(
+			it.Equal(prefixes.Create("http://example.org/a"), "http:a"),
+			it.Equal(iri, "http://example.org/a"),
+		)
+	})
+
+	t.Run("CURIE", func(t *testing.T) {
+		iri := curie.FromURI(prefixes, "wiki:CURIE")
+
+		it.Then(t).Should(
+			it.Equal(iri, "wiki:CURIE"),
+			it.Equal(curie.URI(prefixes, iri), "http://en.wikipedia.org/wiki/CURIE"),
+		)
+	})
+}
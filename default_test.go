@@ -0,0 +1,72 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/fogfish/curie/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestDefaultPrefix(t *testing.T) {
+	ns := curie.Namespaces{
+		curie.DefaultPrefix: "https://example.com/",
+		"wiki":              "http://en.wikipedia.org/wiki/",
+		curie.BlankNode:     "https://example.com/.well-known/genid/",
+	}
+
+	for _, prefixes := range []curie.Prefixes{ns, curie.NewTrie(ns)} {
+		t.Run(fmt.Sprintf("%T", prefixes), func(t *testing.T) {
+			t.Run("Expand", func(t *testing.T) {
+				it.Then(t).Should(
+					it.Equal(curie.URI(prefixes, "CURIE"), "https://example.com/CURIE"),
+					it.Equal(curie.URI(prefixes, "a/b"), "https://example.com/a/b"),
+					it.Equal(curie.URI(prefixes, ":CURIE"), "https://example.com/CURIE"),
+					it.Equal(curie.URI(prefixes, ":"), "https://example.com/"),
+					it.Equal(curie.URI(prefixes, "wiki:CURIE"), "http://en.wikipedia.org/wiki/CURIE"),
+					it.Equal(curie.URI(prefixes, "_:b0"), "_:b0"),
+				)
+			})
+
+			t.Run("Compact", func(t *testing.T) {
+				it.Then(t).Should(
+					it.Equal(curie.FromURI(prefixes, "https://example.com/CURIE"), "CURIE"),
+					it.Equal(curie.FromURI(prefixes, "https://example.com/a/b?c#d"), "a/b?c#d"),
+					it.Equal(curie.FromURI(prefixes, "https://example.com/a:b/c"), ":a:b/c"),
+					it.Equal(curie.FromURI(prefixes, "https://example.com/"), ":"),
+					it.Equal(curie.FromURI(prefixes, "https://example.com/.well-known/genid/b0"), ".well-known/genid/b0"),
+					it.Equal(curie.FromURI(prefixes, "http://en.wikipedia.org/wiki/CURIE"), "wiki:CURIE"),
+				)
+			})
+
+			t.Run("Identity", func(t *testing.T) {
+				for _, uri := range []string{
+					"https://example.com/CURIE",
+					"https://example.com/a:b/c",
+					"https://example.com/",
+				} {
+					it.Then(t).Should(
+						it.Equal(curie.URI(prefixes, curie.FromURI(prefixes, uri)), uri),
+					)
+				}
+			})
+		})
+	}
+}
+
+func TestNoDefaultPrefix(t *testing.T) {
+	prefixes := curie.Namespaces{"wiki": "http://en.wikipedia.org/wiki/"}
+
+	it.Then(t).Should(
+		it.Equal(curie.URI(prefixes, "CURIE"), "CURIE"),
+		it.Equal(curie.URI(prefixes, "_:b0"), "_:b0"),
+	)
+}
@@ -0,0 +1,129 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+)
+
+// String returns compact form of IRI
+func (iri IRI) String() string { return string(iri) }
+
+// MarshalText `IRI ⟼ prefix:suffix`
+func (iri IRI) MarshalText() ([]byte, error) { return []byte(iri), nil }
+
+// UnmarshalText `prefix:suffix ⟼ IRI`, safe CURIE is accepted
+func (iri *IRI) UnmarshalText(b []byte) error {
+	*iri = IRI(unbracket(string(b)))
+	return nil
+}
+
+// MarshalBinary `IRI ⟼ prefix:suffix`
+func (iri IRI) MarshalBinary() ([]byte, error) { return []byte(iri), nil }
+
+// UnmarshalBinary `prefix:suffix ⟼ IRI`
+func (iri *IRI) UnmarshalBinary(b []byte) error {
+	*iri = IRI(b)
+	return nil
+}
+
+// Set implements flag.Value, the value is validated by Parse but the
+// reference might contain colons (e.g. wiki:Talk:CURIE) as in Resolve.
+//
+//	flag.Var(&iri, "id", "identity")
+func (iri *IRI) Set(s string) error {
+	val, err := Parse(unbracket(s), declared())
+	if err != nil {
+		return err
+	}
+
+	*iri = val
+	return nil
+}
+
+// LogValue implements slog.LogValuer, IRI is logged as group of schema and reference
+func (iri IRI) LogValue() slog.Value {
+	schema, ref := Split(iri)
+	return slog.GroupValue(
+		slog.String("schema", schema),
+		slog.String("reference", ref),
+	)
+}
+
+// Format implements fmt.Formatter, other verbs are applied to the string
+//
+//	%s, %v  prefix:suffix
+//	%q      "prefix:suffix"
+//	%+v     [prefix:suffix]
+//	%#v     curie.IRI("prefix:suffix")
+func (iri IRI) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		fmt.Fprintf(f, "curie.IRI(%q)", string(iri))
+	case verb == 'v' && f.Flag('+'):
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), iri.Safe())
+	default:
+		fmt.Fprintf(f, fmt.FormatString(f, verb), string(iri))
+	}
+}
+
+func unbracket(s string) string {
+	if len(s) > 1 && s[0] == '[' && s[len(s)-1] == ']' {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+//------------------------------------------------------------------------------
+
+// String implements flag.Value, prefixes are sorted
+//
+//	ex=https://example.com/ wiki=http://en.wikipedia.org/wiki/
+func (ns Namespaces) String() string {
+	seq := make([]string, 0, len(ns))
+	for prefix, iri := range ns {
+		seq = append(seq, prefix+"="+iri)
+	}
+	sort.Strings(seq)
+
+	return strings.Join(seq, " ")
+}
+
+// Set implements flag.Value, it declares prefix. The flag is repeatable.
+//
+//	flag.Var(&ns, "prefix", "declare prefix (e.g. ex=https://example.com/)")
+//	-prefix ex=https://example.com/ -prefix wiki=http://en.wikipedia.org/wiki/
+func (ns *Namespaces) Set(s string) error {
+	prefix, iri, ok := strings.Cut(strings.TrimSpace(s), "=")
+	if !ok {
+		return fmt.Errorf("%w: %q is not prefix=iri", ErrInvalidPrefix, s)
+	}
+
+	if prefix != DefaultPrefix && !isNCName(prefix) {
+		return fmt.Errorf("%w: %q is not NCName", ErrInvalidPrefix, prefix)
+	}
+
+	if isTemplate(iri) {
+		if _, err := parseTemplate(iri); err != nil {
+			return err
+		}
+	} else if err := parseAbsolute(iri); err != nil {
+		return err
+	}
+
+	if *ns == nil {
+		*ns = Namespaces{}
+	}
+	(*ns)[prefix] = iri
+
+	return nil
+}
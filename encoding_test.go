@@ -0,0 +1,139 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie_test
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/fogfish/curie/v2"
+	"github.com/fogfish/it/v2"
+)
+
+var (
+	_ encoding.TextMarshaler     = curie.IRI("")
+	_ encoding.TextUnmarshaler   = (*curie.IRI)(nil)
+	_ encoding.BinaryMarshaler   = curie.IRI("")
+	_ encoding.BinaryUnmarshaler = (*curie.IRI)(nil)
+	_ flag.Value                 = (*curie.IRI)(nil)
+	_ flag.Value                 = (*curie.Namespaces)(nil)
+	_ slog.LogValuer             = curie.IRI("")
+	_ fmt.Formatter              = curie.IRI("")
+)
+
+func TestText(t *testing.T) {
+	t.Run("MapKey", func(t *testing.T) {
+		val := map[curie.IRI]int{"wiki:CURIE": 1}
+		b, err := json.Marshal(val)
+
+		var out map[curie.IRI]int
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(string(b), `{"wiki:CURIE":1}`),
+			it.Nil(json.Unmarshal([]byte(`{"[wiki:CURIE]":1}`), &out)),
+			it.Equiv(out, val),
+		)
+	})
+
+	t.Run("Gob", func(t *testing.T) {
+		var b bytes.Buffer
+		var out curie.IRI
+
+		it.Then(t).Should(
+			it.Nil(gob.NewEncoder(&b).Encode(curie.IRI("wiki:CURIE"))),
+			it.Nil(gob.NewDecoder(&b).Decode(&out)),
+			it.Equal(out, "wiki:CURIE"),
+		)
+	})
+}
+
+func TestFlag(t *testing.T) {
+	var (
+		iri curie.IRI
+		ns  curie.Namespaces
+	)
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(&iri, "id", "identity")
+	fs.Var(&ns, "prefix", "prefix declaration")
+
+	err := fs.Parse([]string{
+		"-id", "[wiki:Talk:CURIE]",
+		"-prefix", "ex=https://example.com/",
+		"-prefix", "wiki=http://en.wikipedia.org/wiki/",
+	})
+
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(iri, "wiki:Talk:CURIE"),
+		it.Equal(curie.URI(ns, iri), "http://en.wikipedia.org/wiki/Talk:CURIE"),
+		it.Equal(ns.String(), "ex=https://example.com/ wiki=http://en.wikipedia.org/wiki/"),
+		it.Equal(fmt.Sprint(ns), "ex=https://example.com/ wiki=http://en.wikipedia.org/wiki/"),
+	)
+
+	t.Run("Invalid", func(t *testing.T) {
+		var ns curie.Namespaces
+		var iri curie.IRI
+
+		it.Then(t).Should(
+			it.True(errors.Is(ns.Set("ex"), curie.ErrInvalidPrefix)),
+			it.True(errors.Is(ns.Set("1x=https://example.com/"), curie.ErrInvalidPrefix)),
+			it.True(errors.Is(ns.Set("ex=example"), curie.ErrInvalidScheme)),
+			it.True(errors.Is(iri.Set("a:b c"), curie.ErrInvalidReference)),
+		)
+	})
+}
+
+func TestLogValue(t *testing.T) {
+	var b bytes.Buffer
+	log := slog.New(slog.NewTextHandler(&b, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+
+	log.Info("test", "id", curie.IRI("wiki:CURIE"))
+
+	it.Then(t).Should(
+		it.Equal(b.String(), "level=INFO msg=test id.schema=wiki id.reference=CURIE\n"),
+	)
+}
+
+func TestFormat(t *testing.T) {
+	iri := curie.IRI("wiki:CURIE")
+
+	for format, expect := range map[string]string{
+		"%s":   "wiki:CURIE",
+		"%v":   "wiki:CURIE",
+		"%q":   `"wiki:CURIE"`,
+		"%+v":  "[wiki:CURIE]",
+		"%#v":  `curie.IRI("wiki:CURIE")`,
+		"%12s": "  wiki:CURIE",
+		"%x":   "77696b693a4355524945",
+		"% X":  "77 69 6B 69 3A 43 55 52 49 45",
+		"%.4s": "wiki",
+		"%d":   "%!d(string=wiki:CURIE)",
+	} {
+		t.Run(format, func(t *testing.T) {
+			it.Then(t).Should(
+				it.Equal(fmt.Sprintf(format, iri), expect),
+			)
+		})
+	}
+}
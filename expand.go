@@ -0,0 +1,185 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Policy of expansion for CURIEs with unknown prefix
+type Policy int
+
+const (
+	// Strict fails with UnknownPrefixError, absolute IRIs with authority
+	// (e.g. https://example.com/a) or well-known scheme (e.g. urn:isbn:123,
+	// mailto:a@example.com) are accepted as is.
+	Strict Policy = iota
+
+	// PassThrough treats unknown prefix as URI scheme, as URI does.
+	PassThrough
+
+	// FallbackDefault expands the whole CURIE using DefaultPrefix,
+	// it fails if the default prefix is not defined.
+	FallbackDefault
+)
+
+// UnknownPrefixError is returned by strict expansion, it suggests prefixes
+// similar to the unknown one. It matches ErrUnknownPrefix.
+type UnknownPrefixError struct {
+	Prefix      string
+	Suggestions []string
+}
+
+func (e *UnknownPrefixError) Error() string {
+	if len(e.Suggestions) == 0 {
+		return fmt.Sprintf("%s: %q", ErrUnknownPrefix, e.Prefix)
+	}
+
+	return fmt.Sprintf("%s: %q, did you mean %s?", ErrUnknownPrefix, e.Prefix, strings.Join(e.Suggestions, ", "))
+}
+
+func (e *UnknownPrefixError) Unwrap() error { return ErrUnknownPrefix }
+
+// Expand converts CURIE to fully qualified URI, similarly to URI, but reports
+// unknown prefixes according to the policy (Strict by default). Suggestions
+// are computed only for Enumerable prefixes.
+//
+//	wikk:CURIE ⟼ unknown prefix: "wikk", did you mean wiki?
+func Expand(prefixes Prefixes, iri IRI, policy ...Policy) (string, error) {
+	if len(iri) == 0 {
+		return "", nil
+	}
+
+	mode := Strict
+	if len(policy) != 0 {
+		mode = policy[len(policy)-1]
+	}
+
+	schema, ref := Split(iri)
+	if schema == BlankNode {
+		return string(iri), nil
+	}
+
+	if _, exists := prefixes.Lookup(schema); !exists {
+		switch {
+		case mode == PassThrough:
+		case mode == Strict && isScheme(schema) && strings.HasPrefix(ref, "//"):
+		case mode == Strict && isWellKnownScheme(schema):
+		case mode == FallbackDefault:
+			if _, exists := prefixes.Lookup(DefaultPrefix); !exists {
+				return "", unknownPrefix(prefixes, DefaultPrefix)
+			}
+			iri = ":" + iri
+		default:
+			return "", unknownPrefix(prefixes, schema)
+		}
+	}
+
+	uri, err := URL(prefixes, iri)
+	if err != nil {
+		return "", err
+	}
+
+	return uri.String(), nil
+}
+
+// ExpandAll expands sequence of CURIEs, it returns all failures joined.
+// The failed CURIE is expanded to empty string.
+func ExpandAll(prefixes Prefixes, iris []IRI, policy ...Policy) ([]string, error) {
+	seq := make([]string, len(iris))
+	errs := make([]error, 0)
+
+	for i, iri := range iris {
+		uri, err := Expand(prefixes, iri, policy...)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", iri, err))
+			continue
+		}
+		seq[i] = uri
+	}
+
+	return seq, errors.Join(errs...)
+}
+
+func isWellKnownScheme(schema string) bool {
+	_, has := schemes[strings.ToLower(schema)]
+	return has
+}
+
+// maximum number of suggestions
+const suggestions = 3
+
+func unknownPrefix(prefixes Prefixes, prefix string) error {
+	err := &UnknownPrefixError{Prefix: prefix}
+
+	ns, ok := namespacesOf(prefixes)
+	if !ok || len(prefix) == 0 {
+		return err
+	}
+
+	type candidate struct {
+		prefix   string
+		distance int
+	}
+
+	limit := max(1, len(prefix)/3)
+	seq := make([]candidate, 0)
+	for key := range ns {
+		if key == DefaultPrefix || key == BlankNode {
+			continue
+		}
+
+		d := distance(strings.ToLower(prefix), strings.ToLower(key))
+		if d <= limit {
+			seq = append(seq, candidate{key, d})
+		}
+	}
+
+	sort.Slice(seq, func(i, j int) bool {
+		if seq[i].distance != seq[j].distance {
+			return seq[i].distance < seq[j].distance
+		}
+		return seq[i].prefix < seq[j].prefix
+	})
+
+	for i := 0; i < len(seq) && i < suggestions; i++ {
+		err.Suggestions = append(err.Suggestions, seq[i].prefix)
+	}
+
+	return err
+}
+
+// Levenshtein distance of strings
+func distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	row := make([]int, len(rb)+1)
+	for j := range row {
+		row[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		prev := row[0]
+		row[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			next := min(row[j]+1, row[j-1]+1, prev+cost)
+			prev, row[j] = row[j], next
+		}
+	}
+
+	return row[len(rb)]
+}
@@ -0,0 +1,131 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fogfish/curie/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestExpand(t *testing.T) {
+	prefixes := curie.Namespaces{
+		"wiki":     "http://en.wikipedia.org/wiki/",
+		"wikidata": "https://www.wikidata.org/wiki/",
+		"ex":       "https://example.com/",
+	}
+
+	t.Run("Known", func(t *testing.T) {
+		uri, err := curie.Expand(prefixes, "wiki:CURIE")
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(uri, "http://en.wikipedia.org/wiki/CURIE"),
+		)
+	})
+
+	t.Run("Strict", func(t *testing.T) {
+		_, err := curie.Expand(prefixes, "wikk:CURIE", curie.Strict)
+
+		var e *curie.UnknownPrefixError
+		it.Then(t).Should(
+			it.True(errors.Is(err, curie.ErrUnknownPrefix)),
+			it.True(errors.As(err, &e)),
+			it.Equal(e.Prefix, "wikk"),
+			it.Seq(e.Suggestions).Equal("wiki"),
+			it.Equal(err.Error(), `unknown prefix: "wikk", did you mean wiki?`),
+		)
+	})
+
+	t.Run("StrictNoSuggestions", func(t *testing.T) {
+		_, err := curie.Expand(prefixes, "foaf:Person")
+
+		var e *curie.UnknownPrefixError
+		it.Then(t).Should(
+			it.True(errors.As(err, &e)),
+			it.Seq(e.Suggestions).Equal(),
+		)
+	})
+
+	t.Run("StrictAbsolute", func(t *testing.T) {
+		uri, err := curie.Expand(prefixes, "https://example.org/a")
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(uri, "https://example.org/a"),
+		)
+	})
+
+	t.Run("StrictScheme", func(t *testing.T) {
+		uri1, err1 := curie.Expand(prefixes, "urn:isbn:123")
+		uri2, err2 := curie.Expand(prefixes, "mailto:a@example.com")
+
+		it.Then(t).Should(
+			it.Nil(err1),
+			it.Equal(uri1, "urn:isbn:123"),
+			it.Nil(err2),
+			it.Equal(uri2, "mailto:a@example.com"),
+		)
+	})
+
+	t.Run("StrictRelative", func(t *testing.T) {
+		_, err := curie.Expand(prefixes, "a/b")
+
+		it.Then(t).Should(
+			it.True(errors.Is(err, curie.ErrUnknownPrefix)),
+		)
+	})
+
+	t.Run("PassThrough", func(t *testing.T) {
+		uri, err := curie.Expand(prefixes, "wikk:CURIE", curie.PassThrough)
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(uri, "wikk:CURIE"),
+		)
+	})
+
+	t.Run("FallbackDefault", func(t *testing.T) {
+		ns := curie.Namespaces{curie.DefaultPrefix: "https://example.com/"}
+		uri1, err1 := curie.Expand(ns, "urn:a:b", curie.FallbackDefault)
+		uri2, err2 := curie.Expand(ns, "a/b", curie.FallbackDefault)
+		_, err3 := curie.Expand(prefixes, "wikk:CURIE", curie.FallbackDefault)
+
+		it.Then(t).Should(
+			it.Nil(err1),
+			it.Equal(uri1, "https://example.com/urn:a:b"),
+			it.Nil(err2),
+			it.Equal(uri2, "https://example.com/a/b"),
+			it.True(errors.Is(err3, curie.ErrUnknownPrefix)),
+		)
+	})
+
+	t.Run("BlankNode", func(t *testing.T) {
+		uri, err := curie.Expand(prefixes, "_:b0")
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(uri, "_:b0"),
+		)
+	})
+}
+
+func TestExpandAll(t *testing.T) {
+	prefixes := curie.Namespaces{"ex": "https://example.com/"}
+
+	seq, err := curie.ExpandAll(prefixes, []curie.IRI{"ex:a", "ec:b", "ex:c", "xe:d"})
+
+	it.Then(t).Should(
+		it.Seq(seq).Equal("https://example.com/a", "", "https://example.com/c", ""),
+		it.True(errors.Is(err, curie.ErrUnknownPrefix)),
+		it.Equal(err.Error(), "ec:b: unknown prefix: \"ec\", did you mean ex?\nxe:d: unknown prefix: \"xe\""),
+	)
+}
@@ -0,0 +1,182 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+
+	"github.com/fogfish/curie/v2/internal/trie"
+)
+
+// PrefixRecord is the record of extended prefix map (EPM)
+// https://cthoyt.com/2023/01/10/extended-prefix-maps.html
+type PrefixRecord struct {
+	Prefix            string   `json:"prefix"`
+	URIPrefix         string   `json:"uri_prefix"`
+	PrefixSynonyms    []string `json:"prefix_synonyms,omitempty"`
+	URIPrefixSynonyms []string `json:"uri_prefix_synonyms,omitempty"`
+	Pattern           string   `json:"pattern,omitempty"`
+}
+
+// ExtendedPrefixes is immutable collection of prefixes, where each canonical
+// prefix has synonyms, URI prefix variants and the pattern of valid references.
+// The lookup accepts synonyms, the compaction recognizes every variant of
+// URI prefix but always produces canonical prefix.
+//
+//	prefixes, err := curie.NewExtendedPrefixes([]curie.PrefixRecord{
+//		{
+//			Prefix:            "CHEBI",
+//			URIPrefix:         "http://purl.obolibrary.org/obo/CHEBI_",
+//			PrefixSynonyms:    []string{"chebi"},
+//			URIPrefixSynonyms: []string{"https://identifiers.org/CHEBI:"},
+//			Pattern:           "^\\d+$",
+//		},
+//	})
+//
+//	curie.URI(prefixes, "chebi:24867") ⟼ http://purl.obolibrary.org/obo/CHEBI_24867
+//	prefixes.Create("https://identifiers.org/CHEBI:24867") ⟼ CHEBI:24867
+//
+// Note, FromURI is round-trip safe, it compacts only canonical URI prefixes.
+type ExtendedPrefixes struct {
+	records  []PrefixRecord
+	patterns []*regexp.Regexp
+	prefixes map[string]int
+	trie     *trie.Trie
+}
+
+var _ Prefixes = (*ExtendedPrefixes)(nil)
+
+// NewExtendedPrefixes builds extended prefix map from records. Prefixes and
+// synonyms must be NCName, they and URI prefixes must be unique across records.
+func NewExtendedPrefixes(records []PrefixRecord) (*ExtendedPrefixes, error) {
+	epm := &ExtendedPrefixes{
+		records:  make([]PrefixRecord, len(records)),
+		patterns: make([]*regexp.Regexp, len(records)),
+		prefixes: make(map[string]int),
+		trie:     trie.New(preferred),
+	}
+	copy(epm.records, records)
+
+	uris := map[string]string{}
+	for i, record := range epm.records {
+		if len(record.URIPrefix) == 0 {
+			return nil, fmt.Errorf("%w: %q has no URI prefix", ErrInvalidPrefix, record.Prefix)
+		}
+
+		for _, prefix := range append([]string{record.Prefix}, record.PrefixSynonyms...) {
+			if !isNCName(prefix) {
+				return nil, fmt.Errorf("%w: %q is not NCName", ErrInvalidPrefix, prefix)
+			}
+			if at, has := epm.prefixes[prefix]; has && at != i {
+				return nil, fmt.Errorf("%w: %s is defined by %s", ErrPrefixConflict, prefix, epm.records[at].Prefix)
+			}
+			epm.prefixes[prefix] = i
+		}
+
+		for _, uri := range append([]string{record.URIPrefix}, record.URIPrefixSynonyms...) {
+			if prefix, has := uris[uri]; has && prefix != record.Prefix {
+				return nil, fmt.Errorf("%w: %s is bound to %s", ErrExpansionConflict, uri, prefix)
+			}
+			uris[uri] = record.Prefix
+			epm.trie.Put(uri, record.Prefix)
+		}
+
+		if len(record.Pattern) != 0 {
+			re, err := regexp.Compile(record.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("pattern of %s: %w", record.Prefix, err)
+			}
+			epm.patterns[i] = re
+		}
+	}
+
+	return epm, nil
+}
+
+// ReadExtendedPrefixes reads extended prefix map from EPM JSON format,
+// the array of records.
+func ReadExtendedPrefixes(r io.Reader) (*ExtendedPrefixes, error) {
+	var records []PrefixRecord
+	if err := json.NewDecoder(r).Decode(&records); err != nil {
+		return nil, err
+	}
+
+	return NewExtendedPrefixes(records)
+}
+
+// Create new URI using canonical prefixes
+func (epm *ExtendedPrefixes) Create(uri string) IRI {
+	n, prefix, has := epm.trie.Match(uri)
+	if !has {
+		return IRI(uri)
+	}
+
+	return compact(prefix, uri[n:])
+}
+
+// Lookup prefix or its synonym
+func (epm *ExtendedPrefixes) Lookup(prefix string) (string, bool) {
+	at, has := epm.prefixes[prefix]
+	if !has {
+		return "", false
+	}
+
+	return epm.records[at].URIPrefix, true
+}
+
+// Range iterates over canonical prefixes
+func (epm *ExtendedPrefixes) Range(f func(prefix, iri string) bool) {
+	for _, record := range epm.records {
+		if !f(record.Prefix, record.URIPrefix) {
+			return
+		}
+	}
+}
+
+// Record returns the record of prefix or its synonym
+func (epm *ExtendedPrefixes) Record(prefix string) (PrefixRecord, bool) {
+	at, has := epm.prefixes[prefix]
+	if !has {
+		return PrefixRecord{}, false
+	}
+
+	return epm.records[at], true
+}
+
+// Canonical replaces synonym of the prefix with the canonical one
+//
+//	chebi:24867 ⟼ CHEBI:24867
+func (epm *ExtendedPrefixes) Canonical(iri IRI) IRI {
+	schema, ref := Split(iri)
+	at, has := epm.prefixes[schema]
+	if !has || epm.records[at].Prefix == schema {
+		return iri
+	}
+
+	return New(epm.records[at].Prefix, ref)
+}
+
+// Validate checks that prefix of CURIE is defined and its reference matches
+// the pattern of the prefix.
+func (epm *ExtendedPrefixes) Validate(iri IRI) error {
+	schema, ref := Split(iri)
+	at, has := epm.prefixes[schema]
+	if !has {
+		return fmt.Errorf("%w: %s", ErrUnknownPrefix, schema)
+	}
+
+	if re := epm.patterns[at]; re != nil && !re.MatchString(ref) {
+		return fmt.Errorf("%w: %q does not match %s of %s", ErrInvalidReference, ref, re, epm.records[at].Prefix)
+	}
+
+	return nil
+}
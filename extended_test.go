@@ -0,0 +1,146 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/fogfish/curie/v2"
+	"github.com/fogfish/it/v2"
+)
+
+const epm = `[
+	{
+		"prefix": "CHEBI",
+		"uri_prefix": "http://purl.obolibrary.org/obo/CHEBI_",
+		"prefix_synonyms": ["chebi", "ChEBI"],
+		"uri_prefix_synonyms": [
+			"https://identifiers.org/CHEBI:",
+			"https://www.ebi.ac.uk/chebi/searchId.do?chebiId=CHEBI:"
+		],
+		"pattern": "^\\d+$"
+	},
+	{
+		"prefix": "GO",
+		"uri_prefix": "http://purl.obolibrary.org/obo/GO_",
+		"prefix_synonyms": ["go"]
+	}
+]`
+
+func TestExtendedPrefixes(t *testing.T) {
+	prefixes, err := curie.ReadExtendedPrefixes(strings.NewReader(epm))
+	it.Then(t).Should(it.Nil(err))
+
+	t.Run("Lookup", func(t *testing.T) {
+		uri1, has1 := prefixes.Lookup("CHEBI")
+		uri2, has2 := prefixes.Lookup("chebi")
+		_, has3 := prefixes.Lookup("Chebi")
+
+		it.Then(t).Should(
+			it.True(has1),
+			it.True(has2),
+			it.True(!has3),
+			it.Equal(uri1, "http://purl.obolibrary.org/obo/CHEBI_"),
+			it.Equal(uri2, "http://purl.obolibrary.org/obo/CHEBI_"),
+			it.Equal(curie.URI(prefixes, "ChEBI:24867"), "http://purl.obolibrary.org/obo/CHEBI_24867"),
+		)
+	})
+
+	t.Run("Create", func(t *testing.T) {
+		for _, uri := range []string{
+			"http://purl.obolibrary.org/obo/CHEBI_24867",
+			"https://identifiers.org/CHEBI:24867",
+			"https://www.ebi.ac.uk/chebi/searchId.do?chebiId=CHEBI:24867",
+		} {
+			it.Then(t).Should(
+				it.Equal(prefixes.Create(uri), "CHEBI:24867"),
+			)
+		}
+
+		it.Then(t).Should(
+			it.Equal(curie.FromURI(prefixes, "http://purl.obolibrary.org/obo/CHEBI_24867"), "CHEBI:24867"),
+			it.Equal(curie.FromURI(prefixes, "https://identifiers.org/CHEBI:24867"), "https://identifiers.org/CHEBI:24867"),
+			it.Equal(curie.FromURI(prefixes, "http://purl.obolibrary.org/obo/GO_0032571"), "GO:0032571"),
+			it.Equal(curie.FromURI(prefixes, "https://example.com/a"), "https://example.com/a"),
+		)
+	})
+
+	t.Run("Canonical", func(t *testing.T) {
+		it.Then(t).Should(
+			it.Equal(prefixes.Canonical("chebi:24867"), "CHEBI:24867"),
+			it.Equal(prefixes.Canonical("CHEBI:24867"), "CHEBI:24867"),
+			it.Equal(prefixes.Canonical("ex:24867"), "ex:24867"),
+		)
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		it.Then(t).Should(
+			it.Nil(prefixes.Validate("chebi:24867")),
+			it.Nil(prefixes.Validate("GO:anything")),
+			it.True(errors.Is(prefixes.Validate("CHEBI:abc"), curie.ErrInvalidReference)),
+			it.True(errors.Is(prefixes.Validate("ex:24867"), curie.ErrUnknownPrefix)),
+		)
+	})
+
+	t.Run("Range", func(t *testing.T) {
+		seq := []string{}
+		prefixes.Range(func(prefix, iri string) bool {
+			seq = append(seq, prefix)
+			return true
+		})
+
+		it.Then(t).Should(
+			it.Seq(seq).Equal("CHEBI", "GO"),
+		)
+	})
+}
+
+func TestExtendedPrefixesConflict(t *testing.T) {
+	_, err1 := curie.NewExtendedPrefixes([]curie.PrefixRecord{
+		{Prefix: "a", URIPrefix: "https://a.example/", PrefixSynonyms: []string{"x"}},
+		{Prefix: "b", URIPrefix: "https://b.example/", PrefixSynonyms: []string{"x"}},
+	})
+
+	_, err2 := curie.NewExtendedPrefixes([]curie.PrefixRecord{
+		{Prefix: "a", URIPrefix: "https://a.example/"},
+		{Prefix: "b", URIPrefix: "https://b.example/", URIPrefixSynonyms: []string{"https://a.example/"}},
+	})
+
+	_, err3 := curie.NewExtendedPrefixes([]curie.PrefixRecord{
+		{Prefix: "a", URIPrefix: "https://a.example/", Pattern: "(("},
+	})
+
+	_, err4 := curie.NewExtendedPrefixes([]curie.PrefixRecord{
+		{Prefix: "a"},
+	})
+
+	it.Then(t).Should(
+		it.True(errors.Is(err1, curie.ErrPrefixConflict)),
+		it.True(errors.Is(err2, curie.ErrExpansionConflict)),
+		it.True(err3 != nil),
+		it.True(errors.Is(err4, curie.ErrInvalidPrefix)),
+	)
+
+	for _, record := range []curie.PrefixRecord{
+		{Prefix: "", URIPrefix: "https://a.example/"},
+		{Prefix: "a:b", URIPrefix: "https://a.example/"},
+		{Prefix: "1bad", URIPrefix: "https://a.example/"},
+		{Prefix: "a b", URIPrefix: "https://a.example/"},
+		{Prefix: "a", URIPrefix: "https://a.example/", PrefixSynonyms: []string{"1bad"}},
+		{Prefix: "a", URIPrefix: "https://a.example/", PrefixSynonyms: []string{"a b"}},
+	} {
+		_, err := curie.NewExtendedPrefixes([]curie.PrefixRecord{record})
+
+		it.Then(t).Should(
+			it.True(errors.Is(err, curie.ErrInvalidPrefix)),
+		)
+	}
+}
@@ -2,4 +2,7 @@ module github.com/fogfish/curie/v2
 
 go 1.22
 
-require github.com/fogfish/it/v2 v2.0.1
+require (
+	github.com/fogfish/it/v2 v2.0.1
+	golang.org/x/text v0.21.0
+)
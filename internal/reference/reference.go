@@ -66,3 +66,54 @@ func Split(ref string, delim rune, n int) string {
 
 	return ref[:x]
 }
+
+// RemoveDotSegments interprets and removes "." and ".." segments from path,
+// as defined by RFC 3986 section 5.2.4
+func RemoveDotSegments(path string) string {
+	if strings.IndexByte(path, '.') == -1 {
+		return path
+	}
+
+	in := path
+	out := make([]byte, 0, len(path))
+
+	removeLastSegment := func() {
+		n := strings.LastIndexByte(string(out), '/')
+		if n == -1 {
+			n = 0
+		}
+		out = out[:n]
+	}
+
+	for len(in) > 0 {
+		switch {
+		case strings.HasPrefix(in, "../"):
+			in = in[3:]
+		case strings.HasPrefix(in, "./"):
+			in = in[2:]
+		case strings.HasPrefix(in, "/./"):
+			in = in[2:]
+		case in == "/.":
+			in = "/"
+		case strings.HasPrefix(in, "/../"):
+			in = in[3:]
+			removeLastSegment()
+		case in == "/..":
+			in = "/"
+			removeLastSegment()
+		case in == "." || in == "..":
+			in = ""
+		default:
+			n := strings.IndexByte(in[1:], '/')
+			if n == -1 {
+				n = len(in)
+			} else {
+				n++
+			}
+			out = append(out, in[:n]...)
+			in = in[n:]
+		}
+	}
+
+	return string(out)
+}
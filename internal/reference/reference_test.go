@@ -81,3 +81,28 @@ func TestSplit(t *testing.T) {
 		),
 	)
 }
+
+func TestRemoveDotSegments(t *testing.T) {
+	for path, expected := range map[string]string{
+		"":                   "",
+		"/a/b/c/./../../g":   "/a/g",
+		"mid/content=5/../6": "mid/6",
+		"/a/b/c":             "/a/b/c",
+		"/./a":               "/a",
+		"/a/.":               "/a/",
+		"/a/..":              "/",
+		"/../a":              "/a",
+		"../a":               "a",
+		"./a":                "a",
+		".":                  "",
+		"..":                 "",
+		"/a/b/../../..":      "/",
+		"/a.b/c..d/.e":       "/a.b/c..d/.e",
+	} {
+		t.Run(fmt.Sprintf("(%s)", path), func(t *testing.T) {
+			it.Then(t).Should(
+				it.Equal(reference.RemoveDotSegments(path), expected),
+			)
+		})
+	}
+}
@@ -0,0 +1,227 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package trie
+
+import "sort"
+
+// Trie is byte-wise prefix tree, it associates keys with the set of values.
+// The set is ordered, first value is the preferred one.
+type Trie struct {
+	root node
+	less func(a, b string) bool
+}
+
+type node struct {
+	children map[byte]*node
+	values   []string
+}
+
+// New creates empty trie, the values are ordered using less
+func New(less func(a, b string) bool) *Trie {
+	return &Trie{less: less}
+}
+
+// Put associates value with the key
+func (t *Trie) Put(key, val string) {
+	n := &t.root
+	for i := 0; i < len(key); i++ {
+		if n.children == nil {
+			n.children = make(map[byte]*node)
+		}
+
+		next, has := n.children[key[i]]
+		if !has {
+			next = &node{}
+			n.children[key[i]] = next
+		}
+		n = next
+	}
+
+	n.values = t.insert(n.values, val)
+}
+
+// insert value into ordered set
+func (t *Trie) insert(values []string, val string) []string {
+	at := sort.Search(len(values), func(i int) bool { return !t.less(values[i], val) })
+	if at < len(values) && values[at] == val {
+		return values
+	}
+
+	values = append(values, "")
+	copy(values[at+1:], values[at:])
+	values[at] = val
+	return values
+}
+
+// Remove value associated with the key
+func (t *Trie) Remove(key, val string) {
+	path := make([]*node, 0, len(key)+1)
+
+	n := &t.root
+	path = append(path, n)
+	for i := 0; i < len(key); i++ {
+		next, has := n.children[key[i]]
+		if !has {
+			return
+		}
+		n = next
+		path = append(path, n)
+	}
+
+	for i, x := range n.values {
+		if x == val {
+			n.values = append(n.values[:i], n.values[i+1:]...)
+			break
+		}
+	}
+
+	// prune empty branches
+	for i := len(key) - 1; i >= 0; i-- {
+		child := path[i+1]
+		if len(child.values) != 0 || len(child.children) != 0 {
+			break
+		}
+		delete(path[i].children, key[i])
+	}
+}
+
+// With returns copy of the trie, which associates value with the key.
+// The copy shares unchanged branches with the original trie, which is
+// not modified. Do not use Put or Remove on tries sharing branches.
+func (t *Trie) With(key, val string) *Trie {
+	c := &Trie{root: t.root, less: t.less}
+
+	n := &c.root
+	for i := 0; i < len(key); i++ {
+		next := &node{}
+		if x, has := n.children[key[i]]; has {
+			*next = *x
+		}
+		n.children = with(n.children, key[i], next)
+		n = next
+	}
+
+	n.values = t.insert(append([]string(nil), n.values...), val)
+	return c
+}
+
+// Without returns copy of the trie, which does not associate value with the
+// key. The copy shares unchanged branches with the original trie.
+func (t *Trie) Without(key, val string) *Trie {
+	values := t.Get(key)
+	at := -1
+	for i, x := range values {
+		if x == val {
+			at = i
+		}
+	}
+	if at == -1 {
+		return t
+	}
+
+	c := &Trie{root: t.root, less: t.less}
+	path := make([]*node, 0, len(key)+1)
+
+	n := &c.root
+	path = append(path, n)
+	for i := 0; i < len(key); i++ {
+		next := &node{}
+		*next = *n.children[key[i]]
+		n.children = with(n.children, key[i], next)
+		n = next
+		path = append(path, n)
+	}
+
+	n.values = append(append([]string(nil), values[:at]...), values[at+1:]...)
+
+	// prune empty branches, the path is copied
+	for i := len(key) - 1; i >= 0; i-- {
+		child := path[i+1]
+		if len(child.values) != 0 || len(child.children) != 0 {
+			break
+		}
+		delete(path[i].children, key[i])
+	}
+
+	return c
+}
+
+// copy of children with the new child
+func with(children map[byte]*node, b byte, n *node) map[byte]*node {
+	c := make(map[byte]*node, len(children)+1)
+	for k, v := range children {
+		c[k] = v
+	}
+	c[b] = n
+	return c
+}
+
+// Get values associated with the key, the slice must not be modified
+func (t *Trie) Get(key string) []string {
+	n := &t.root
+	for i := 0; i < len(key); i++ {
+		next, has := n.children[key[i]]
+		if !has {
+			return nil
+		}
+		n = next
+	}
+
+	return n.values
+}
+
+// Walk iterates over keys and values of the trie, the order is not defined
+func (t *Trie) Walk(f func(key, val string) bool) {
+	t.root.walk(nil, f)
+}
+
+func (n *node) walk(key []byte, f func(key, val string) bool) bool {
+	for _, val := range n.values {
+		if !f(string(key), val) {
+			return false
+		}
+	}
+
+	for b, child := range n.children {
+		if !child.walk(append(key, b), f) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Match finds the longest key that is prefix of the string,
+// it returns the length of the key and the preferred value.
+func (t *Trie) Match(s string) (int, string, bool) {
+	var (
+		length int
+		value  string
+		found  bool
+	)
+
+	n := &t.root
+	for i := 0; ; i++ {
+		if len(n.values) != 0 {
+			length, value, found = i, n.values[0], true
+		}
+
+		if i == len(s) {
+			break
+		}
+
+		next, has := n.children[s[i]]
+		if !has {
+			break
+		}
+		n = next
+	}
+
+	return length, value, found
+}
@@ -0,0 +1,144 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package trie_test
+
+import (
+	"testing"
+
+	"github.com/fogfish/curie/v2/internal/trie"
+	"github.com/fogfish/it/v2"
+)
+
+func less(a, b string) bool { return a < b }
+
+func TestMatch(t *testing.T) {
+	tr := trie.New(less)
+	tr.Put("https://example.com/", "ex")
+	tr.Put("https://example.com/a/", "exa")
+
+	for s, expected := range map[string]struct {
+		n   int
+		val string
+		ok  bool
+	}{
+		"":                        {0, "", false},
+		"https://example.org/":    {0, "", false},
+		"https://example.com/":    {20, "ex", true},
+		"https://example.com/b":   {20, "ex", true},
+		"https://example.com/a":   {20, "ex", true},
+		"https://example.com/a/":  {22, "exa", true},
+		"https://example.com/a/b": {22, "exa", true},
+	} {
+		n, val, ok := tr.Match(s)
+		it.Then(t).Should(
+			it.Equal(n, expected.n),
+			it.Equal(val, expected.val),
+			it.Equal(ok, expected.ok),
+		)
+	}
+}
+
+func TestPreferred(t *testing.T) {
+	tr := trie.New(less)
+	tr.Put("https://example.com/", "b")
+	tr.Put("https://example.com/", "c")
+	tr.Put("https://example.com/", "a")
+	tr.Put("https://example.com/", "a")
+
+	_, val1, _ := tr.Match("https://example.com/x")
+	tr.Remove("https://example.com/", "a")
+	_, val2, _ := tr.Match("https://example.com/x")
+
+	it.Then(t).Should(
+		it.Equal(val1, "a"),
+		it.Equal(val2, "b"),
+	)
+}
+
+func TestRemove(t *testing.T) {
+	tr := trie.New(less)
+	tr.Put("https://example.com/", "ex")
+	tr.Put("https://example.com/a/", "exa")
+
+	tr.Remove("https://example.com/a/", "exa")
+	tr.Remove("https://example.com/a/", "unknown")
+	tr.Remove("https://example.org/", "ex")
+	n1, val1, _ := tr.Match("https://example.com/a/b")
+
+	tr.Remove("https://example.com/", "ex")
+	_, _, ok2 := tr.Match("https://example.com/a/b")
+
+	it.Then(t).Should(
+		it.Equal(n1, 20),
+		it.Equal(val1, "ex"),
+		it.Equal(ok2, false),
+	)
+}
+
+func TestWith(t *testing.T) {
+	tr1 := trie.New(less)
+	tr1.Put("https://example.com/", "ex")
+
+	tr2 := tr1.With("https://example.com/a/", "exa")
+	tr3 := tr2.With("https://example.com/", "e")
+
+	_, val1, _ := tr1.Match("https://example.com/a/b")
+	_, val2, _ := tr2.Match("https://example.com/a/b")
+	_, val3, _ := tr3.Match("https://example.com/b")
+
+	it.Then(t).Should(
+		it.Equal(val1, "ex"),
+		it.Equal(val2, "exa"),
+		it.Equal(val3, "e"),
+		it.Seq(tr1.Get("https://example.com/")).Equal("ex"),
+		it.Seq(tr3.Get("https://example.com/")).Equal("e", "ex"),
+		it.Equal(len(tr1.Get("https://example.com/a/")), 0),
+	)
+}
+
+func TestWithout(t *testing.T) {
+	tr1 := trie.New(less)
+	tr1.Put("https://example.com/", "ex")
+	tr1.Put("https://example.com/a/", "exa")
+
+	tr2 := tr1.Without("https://example.com/a/", "exa")
+	tr3 := tr2.Without("https://example.com/", "ex")
+
+	_, val1, _ := tr1.Match("https://example.com/a/b")
+	_, val2, _ := tr2.Match("https://example.com/a/b")
+	_, _, ok3 := tr3.Match("https://example.com/a/b")
+
+	it.Then(t).Should(
+		it.Equal(val1, "exa"),
+		it.Equal(val2, "ex"),
+		it.Equal(ok3, false),
+		it.Equal(tr2.Without("https://example.com/", "unknown"), tr2),
+	)
+}
+
+func TestWalk(t *testing.T) {
+	tr := trie.New(less)
+	tr.Put("https://example.com/", "ex")
+	tr.Put("https://example.com/a/", "exa")
+	tr.Put("https://example.com/", "e")
+
+	seq := map[string]string{}
+	tr.Walk(func(key, val string) bool {
+		seq[val] = key
+		return true
+	})
+
+	it.Then(t).Should(
+		it.Equiv(seq, map[string]string{
+			"e":   "https://example.com/",
+			"ex":  "https://example.com/",
+			"exa": "https://example.com/a/",
+		}),
+	)
+}
@@ -0,0 +1,191 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// JSONMode is the wire format of IRI
+type JSONMode int
+
+const (
+	// JSONCompact `IRI ⟼ "prefix:suffix"`
+	JSONCompact JSONMode = iota
+
+	// JSONSafe `IRI ⟼ "[prefix:suffix]"`, absolute IRIs are not bracketed
+	JSONSafe
+
+	// JSONExpanded `IRI ⟼ "https://example.com/suffix"`
+	JSONExpanded
+
+	// JSONNode `IRI ⟼ {"@id": "https://example.com/suffix"}`, JSON-LD node reference
+	JSONNode
+)
+
+// JSONCodec is prefix-aware JSON codec of IRI. It encodes IRI using the mode,
+// the decoding accepts any of modes and compacts absolute IRIs using prefixes.
+//
+//	codec := curie.JSONCodec{Prefixes: prefixes, Mode: curie.JSONExpanded}
+//	codec.Marshal("wiki:CURIE") ⟼ "http://en.wikipedia.org/wiki/CURIE"
+type JSONCodec struct {
+	Prefixes Prefixes
+	Mode     JSONMode
+}
+
+// Marshal IRI using the mode of codec
+func (c JSONCodec) Marshal(iri IRI) ([]byte, error) {
+	if len(iri) == 0 {
+		if c.Mode == JSONNode {
+			return []byte("null"), nil
+		}
+		return json.Marshal("")
+	}
+
+	uri := URI(c.prefixes(), iri)
+
+	switch c.Mode {
+	case JSONCompact:
+		return json.Marshal(FromURI(c.prefixes(), uri))
+	case JSONSafe:
+		compact := FromURI(c.prefixes(), uri)
+		if string(compact) == uri {
+			return json.Marshal(uri)
+		}
+		return json.Marshal(compact.Safe())
+	case JSONExpanded:
+		return json.Marshal(uri)
+	case JSONNode:
+		return json.Marshal(struct {
+			ID string `json:"@id"`
+		}{uri})
+	default:
+		return nil, fmt.Errorf("curie: unknown JSON mode %d", c.Mode)
+	}
+}
+
+// Unmarshal IRI from any of modes, absolute IRI is compacted using prefixes.
+// The reference of declared prefix might contain colons (e.g. wiki:Talk:CURIE),
+// so that every value produced by Marshal is accepted.
+func (c JSONCodec) Unmarshal(b []byte, iri *IRI) error {
+	b = bytes.TrimSpace(b)
+
+	var val string
+	switch {
+	case bytes.Equal(b, []byte("null")):
+		*iri = Empty
+		return nil
+	case len(b) > 0 && b[0] == '{':
+		var node struct {
+			ID *string `json:"@id"`
+		}
+		if err := json.Unmarshal(b, &node); err != nil {
+			return err
+		}
+		if node.ID == nil {
+			return fmt.Errorf("curie: node reference without @id")
+		}
+		val = *node.ID
+	default:
+		if err := json.Unmarshal(b, &val); err != nil {
+			return err
+		}
+	}
+
+	if len(val) == 0 {
+		*iri = Empty
+		return nil
+	}
+
+	v, kind, err := Resolve(c.prefixes(), val)
+	if err != nil {
+		return err
+	}
+
+	if kind == KindIRI {
+		v = FromURI(c.prefixes(), string(v))
+	}
+
+	*iri = v
+	return nil
+}
+
+func (c JSONCodec) prefixes() Prefixes {
+	if c.Prefixes == nil {
+		return Namespaces{}
+	}
+	return c.Prefixes
+}
+
+// Wrap binds IRI with the codec, the result implements json.Marshaler and
+// json.Unmarshaler. Use it for custom encoding of structures.
+//
+//	json.Marshal(codec.Wrap(&iri))
+func (c JSONCodec) Wrap(iri *IRI) *JSONValue { return &JSONValue{Codec: c, IRI: iri} }
+
+// JSONValue is IRI bound with the codec
+type JSONValue struct {
+	Codec JSONCodec
+	IRI   *IRI
+}
+
+// MarshalJSON IRI using the codec
+func (v JSONValue) MarshalJSON() ([]byte, error) {
+	if v.IRI == nil {
+		return []byte("null"), nil
+	}
+	return v.Codec.Marshal(*v.IRI)
+}
+
+// UnmarshalJSON IRI using the codec
+func (v JSONValue) UnmarshalJSON(b []byte) error {
+	if v.IRI == nil {
+		return fmt.Errorf("curie: cannot unmarshal into nil IRI")
+	}
+	return v.Codec.Unmarshal(b, v.IRI)
+}
+
+//------------------------------------------------------------------------------
+
+// JSONProfile declares the codec used by Expanded field type
+//
+//	type Public struct{}
+//
+//	func (Public) JSONCodec() curie.JSONCodec {
+//		return curie.JSONCodec{Prefixes: prefixes, Mode: curie.JSONExpanded}
+//	}
+type JSONProfile interface {
+	JSONCodec() JSONCodec
+}
+
+// Expanded is IRI encoded by the codec of the profile. The profile is defined
+// by the type, so that the same identity is serialized differently for
+// internal and external consumers.
+//
+//	type Person struct {
+//		ID curie.Expanded[Public] `json:"id"`
+//	}
+type Expanded[T JSONProfile] IRI
+
+// ToIRI returns the identity
+func (iri Expanded[T]) ToIRI() IRI { return IRI(iri) }
+
+// MarshalJSON IRI using the codec of profile
+func (iri Expanded[T]) MarshalJSON() ([]byte, error) {
+	var profile T
+	return profile.JSONCodec().Marshal(IRI(iri))
+}
+
+// UnmarshalJSON IRI using the codec of profile
+func (iri *Expanded[T]) UnmarshalJSON(b []byte) error {
+	var profile T
+	return profile.JSONCodec().Unmarshal(b, (*IRI)(iri))
+}
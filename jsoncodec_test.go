@@ -0,0 +1,185 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/fogfish/curie/v2"
+	"github.com/fogfish/it/v2"
+)
+
+var codecPrefixes = curie.Namespaces{
+	"wiki": "http://en.wikipedia.org/wiki/",
+}
+
+func TestJSONCodec(t *testing.T) {
+	for mode, expect := range map[curie.JSONMode]string{
+		curie.JSONCompact:  `"wiki:CURIE"`,
+		curie.JSONSafe:     `"[wiki:CURIE]"`,
+		curie.JSONExpanded: `"http://en.wikipedia.org/wiki/CURIE"`,
+		curie.JSONNode:     `{"@id":"http://en.wikipedia.org/wiki/CURIE"}`,
+	} {
+		codec := curie.JSONCodec{Prefixes: codecPrefixes, Mode: mode}
+
+		t.Run(expect, func(t *testing.T) {
+			b, err := codec.Marshal("wiki:CURIE")
+
+			var iri curie.IRI
+			it.Then(t).Should(
+				it.Nil(err),
+				it.Equal(string(b), expect),
+				it.Nil(codec.Unmarshal(b, &iri)),
+				it.Equal(iri, "wiki:CURIE"),
+			)
+		})
+	}
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		prefixes := curie.Namespaces{
+			curie.DefaultPrefix: "https://example.com/",
+			"wiki":              "http://en.wikipedia.org/wiki/",
+		}
+
+		for _, mode := range []curie.JSONMode{curie.JSONCompact, curie.JSONSafe, curie.JSONExpanded, curie.JSONNode} {
+			codec := curie.JSONCodec{Prefixes: prefixes, Mode: mode}
+
+			for _, uri := range []string{
+				"http://en.wikipedia.org/wiki/Talk:CURIE",
+				"http://en.wikipedia.org/wiki/a:b/c",
+				"https://example.com/a:b",
+				"https://example.com/a/b:c",
+				"urn:isbn:0451450523",
+			} {
+				b, err := codec.Marshal(curie.IRI(uri))
+
+				var iri curie.IRI
+				it.Then(t).Should(
+					it.Nil(err),
+					it.Nil(codec.Unmarshal(b, &iri)),
+					it.Equal(curie.URI(prefixes, iri), uri),
+				)
+			}
+		}
+
+		var iri curie.IRI
+		codec := curie.JSONCodec{Prefixes: prefixes}
+		it.Then(t).Should(
+			it.Nil(codec.Unmarshal([]byte(`"wiki:Talk:CURIE"`), &iri)),
+			it.Equal(iri, "wiki:Talk:CURIE"),
+			it.Nil(codec.Unmarshal([]byte(`"[wiki:a:b]"`), &iri)),
+			it.Equal(iri, "wiki:a:b"),
+			it.Nil(codec.Unmarshal([]byte(`":a:b"`), &iri)),
+			it.Equal(iri, ":a:b"),
+		)
+	})
+
+	t.Run("Compaction", func(t *testing.T) {
+		codec := curie.JSONCodec{Prefixes: codecPrefixes, Mode: curie.JSONCompact}
+		b, err := codec.Marshal("http://en.wikipedia.org/wiki/CURIE")
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(string(b), `"wiki:CURIE"`),
+		)
+	})
+
+	t.Run("Absolute", func(t *testing.T) {
+		codec := curie.JSONCodec{Prefixes: codecPrefixes, Mode: curie.JSONSafe}
+		b, err := codec.Marshal("https://example.com/a")
+
+		var iri curie.IRI
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(string(b), `"https://example.com/a"`),
+			it.Nil(codec.Unmarshal(b, &iri)),
+			it.Equal(iri, "https://example.com/a"),
+		)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		b1, err1 := curie.JSONCodec{Mode: curie.JSONExpanded}.Marshal(curie.Empty)
+		b2, err2 := curie.JSONCodec{Mode: curie.JSONNode}.Marshal(curie.Empty)
+
+		iri := curie.IRI("a:b")
+		err3 := curie.JSONCodec{}.Unmarshal([]byte("null"), &iri)
+
+		it.Then(t).Should(
+			it.Nil(err1),
+			it.Equal(string(b1), `""`),
+			it.Nil(err2),
+			it.Equal(string(b2), `null`),
+			it.Nil(err3),
+			it.Equal(iri, curie.Empty),
+		)
+	})
+
+	t.Run("UnknownPrefix", func(t *testing.T) {
+		var iri curie.IRI
+		err1 := curie.JSONCodec{Prefixes: codecPrefixes}.Unmarshal([]byte(`"[wikk:CURIE]"`), &iri)
+		err2 := curie.JSONCodec{Prefixes: codecPrefixes}.Unmarshal([]byte(`{"id": "wiki:CURIE"}`), &iri)
+
+		it.Then(t).Should(
+			it.True(errors.Is(err1, curie.ErrUnknownPrefix)),
+			it.True(err2 != nil),
+		)
+	})
+
+	t.Run("Wrap", func(t *testing.T) {
+		codec := curie.JSONCodec{Prefixes: codecPrefixes, Mode: curie.JSONExpanded}
+
+		iri := curie.IRI("wiki:CURIE")
+		b, err := json.Marshal(map[string]any{"id": codec.Wrap(&iri)})
+
+		var val curie.IRI
+		err2 := json.Unmarshal([]byte(`"http://en.wikipedia.org/wiki/CURIE"`), codec.Wrap(&val))
+		err3 := curie.JSONValue{Codec: codec}.UnmarshalJSON([]byte(`"wiki:CURIE"`))
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(string(b), `{"id":"http://en.wikipedia.org/wiki/CURIE"}`),
+			it.Nil(err2),
+			it.Equal(val, "wiki:CURIE"),
+			it.True(err3 != nil),
+		)
+	})
+}
+
+type public struct{}
+
+func (public) JSONCodec() curie.JSONCodec {
+	return curie.JSONCodec{Prefixes: codecPrefixes, Mode: curie.JSONExpanded}
+}
+
+type linkedData struct{}
+
+func (linkedData) JSONCodec() curie.JSONCodec {
+	return curie.JSONCodec{Prefixes: codecPrefixes, Mode: curie.JSONNode}
+}
+
+func TestExpanded(t *testing.T) {
+	type Page struct {
+		ID   curie.Expanded[public]     `json:"id"`
+		Link curie.Expanded[linkedData] `json:"link"`
+	}
+
+	page := Page{ID: "wiki:CURIE", Link: "wiki:URI"}
+	b, err := json.Marshal(page)
+
+	var val Page
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(string(b), `{"id":"http://en.wikipedia.org/wiki/CURIE","link":{"@id":"http://en.wikipedia.org/wiki/URI"}}`),
+		it.Nil(json.Unmarshal(b, &val)),
+		it.Equal(val, page),
+		it.Equal(val.ID.ToIRI(), "wiki:CURIE"),
+	)
+}
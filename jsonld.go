@@ -0,0 +1,382 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ErrInvalidContext is returned when JSON-LD context cannot be processed
+var ErrInvalidContext = errors.New("invalid JSON-LD context")
+
+// ReadContext reads prefixes from JSON-LD 1.1 context, either the document
+// with `@context` or the context definition itself.
+// https://www.w3.org/TR/json-ld11/#the-context
+//
+// The term is prefix if it is simple term definition, whose IRI ends with
+// gen-delim character, or expanded term definition with `@prefix: true`.
+// The `@vocab` is read as DefaultPrefix, relative vocabulary is resolved
+// against `@base`. Arrays of contexts are processed in order, `null` resets
+// the active context. Remote contexts are not supported.
+//
+//	{"@context": {"foaf": "http://xmlns.com/foaf/0.1/"}}
+func ReadContext(r io.Reader) (Namespaces, error) {
+	var raw json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	var doc map[string]json.RawMessage
+	if json.Unmarshal(raw, &doc) == nil {
+		if ctx, has := doc["@context"]; has {
+			raw = ctx
+		}
+	}
+
+	c := &jsonldContext{terms: map[string]jsonldTerm{}}
+	if err := c.process(raw); err != nil {
+		return nil, err
+	}
+
+	ns := Namespaces{}
+	for term, def := range c.terms {
+		if def.prefix && term != BlankNode && isNCName(term) {
+			ns[term] = def.iri
+		}
+	}
+
+	if c.hasVocab {
+		ns[DefaultPrefix] = c.vocab
+	}
+
+	return ns, nil
+}
+
+// WriteContext writes prefixes as JSON-LD context document, terms are sorted.
+// The DefaultPrefix is written as `@vocab`, expansions not ending with
+// gen-delim character are written as expanded term definitions with `@prefix: true`.
+func (ns Namespaces) WriteContext(w io.Writer) error {
+	terms := make([]string, 0, len(ns))
+	for prefix := range ns {
+		if prefix != DefaultPrefix && prefix != BlankNode {
+			terms = append(terms, prefix)
+		}
+	}
+	sort.Strings(terms)
+
+	b := bufio.NewWriter(w)
+	b.WriteString("{\n  \"@context\": {")
+
+	sep := "\n"
+	if vocab, has := ns[DefaultPrefix]; has {
+		b.WriteString(sep)
+		writeContextTerm(b, "@vocab", vocab, false)
+		sep = ",\n"
+	}
+
+	for _, term := range terms {
+		b.WriteString(sep)
+		writeContextTerm(b, term, ns[term], !isGenDelimSuffix(ns[term]))
+		sep = ",\n"
+	}
+
+	if sep != "\n" {
+		b.WriteString("\n  ")
+	}
+	b.WriteString("}\n}\n")
+
+	return b.Flush()
+}
+
+func writeContextTerm(w *bufio.Writer, term, iri string, expanded bool) {
+	w.WriteString("    ")
+	w.Write(jsonString(term))
+	w.WriteString(": ")
+	if !expanded {
+		w.Write(jsonString(iri))
+		return
+	}
+
+	w.WriteString("{\"@id\": ")
+	w.Write(jsonString(iri))
+	w.WriteString(", \"@prefix\": true}")
+}
+
+func jsonString(s string) []byte {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	return bytes.TrimRight(b.Bytes(), "\n")
+}
+
+// checks if IRI ends with gen-delim character, RFC 3986
+func isGenDelimSuffix(iri string) bool {
+	return len(iri) > 0 && strings.IndexByte(":/?#[]@", iri[len(iri)-1]) != -1
+}
+
+//------------------------------------------------------------------------------
+
+type jsonldTerm struct {
+	iri    string
+	prefix bool
+}
+
+// active context, the subset required for prefixes
+type jsonldContext struct {
+	terms    map[string]jsonldTerm
+	vocab    string
+	hasVocab bool
+	base     string
+}
+
+func (c *jsonldContext) process(raw json.RawMessage) error {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return fmt.Errorf("%w: empty context", ErrInvalidContext)
+	}
+
+	switch raw[0] {
+	case 'n':
+		*c = jsonldContext{terms: map[string]jsonldTerm{}}
+		return nil
+	case '[':
+		var seq []json.RawMessage
+		if err := json.Unmarshal(raw, &seq); err != nil {
+			return err
+		}
+		for _, x := range seq {
+			if err := c.process(x); err != nil {
+				return err
+			}
+		}
+		return nil
+	case '"':
+		var ref string
+		json.Unmarshal(raw, &ref)
+		return fmt.Errorf("%w: remote context %q is not supported", ErrInvalidContext, ref)
+	case '{':
+		var local map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &local); err != nil {
+			return err
+		}
+		return c.define(local)
+	default:
+		return fmt.Errorf("%w: context is not an object", ErrInvalidContext)
+	}
+}
+
+// process context definition, @base and @vocab are processed before terms
+func (c *jsonldContext) define(local map[string]json.RawMessage) error {
+	if _, has := local["@import"]; has {
+		return fmt.Errorf("%w: @import is not supported", ErrInvalidContext)
+	}
+
+	if raw, has := local["@base"]; has {
+		var base *string
+		if err := json.Unmarshal(raw, &base); err != nil {
+			return fmt.Errorf("%w: @base: %w", ErrInvalidContext, err)
+		}
+
+		c.base = ""
+		if base != nil {
+			iri, err := c.resolve(*base)
+			if err != nil {
+				return err
+			}
+			c.base = iri
+		}
+	}
+
+	if raw, has := local["@vocab"]; has {
+		var vocab *string
+		if err := json.Unmarshal(raw, &vocab); err != nil {
+			return fmt.Errorf("%w: @vocab: %w", ErrInvalidContext, err)
+		}
+
+		c.vocab, c.hasVocab = "", false
+		if vocab != nil {
+			iri, err := c.expandVocab(*vocab)
+			if err != nil {
+				return err
+			}
+			c.vocab, c.hasVocab = iri, true
+		}
+	}
+
+	defined := map[string]bool{}
+	for term := range local {
+		if strings.HasPrefix(term, "@") {
+			continue
+		}
+
+		if err := c.term(term, local, defined); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// create term definition, terms referenced by the definition are created first
+func (c *jsonldContext) term(term string, local map[string]json.RawMessage, defined map[string]bool) error {
+	if done, has := defined[term]; has {
+		if !done {
+			return fmt.Errorf("%w: cyclic IRI mapping of %q", ErrInvalidContext, term)
+		}
+		return nil
+	}
+	defined[term] = false
+
+	var def struct {
+		ID      *string `json:"@id"`
+		Prefix  bool    `json:"@prefix"`
+		Reverse *string `json:"@reverse"`
+	}
+	id, simple := term, false
+
+	raw := bytes.TrimSpace(local[term])
+	switch {
+	case bytes.Equal(raw, []byte("null")):
+		delete(c.terms, term)
+		defined[term] = true
+		return nil
+	case len(raw) > 0 && raw[0] == '"':
+		json.Unmarshal(raw, &id)
+		simple = true
+	case len(raw) > 0 && raw[0] == '{':
+		if err := json.Unmarshal(raw, &def); err != nil {
+			return fmt.Errorf("%w: term %q: %w", ErrInvalidContext, term, err)
+		}
+
+		var keys map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &keys); err != nil {
+			return fmt.Errorf("%w: term %q: %w", ErrInvalidContext, term, err)
+		}
+
+		_, hasID := keys["@id"]
+		switch {
+		case def.Reverse != nil || (hasID && def.ID == nil):
+			// reverse properties and decoupled terms are not prefixes
+			delete(c.terms, term)
+			defined[term] = true
+			return nil
+		case def.ID != nil:
+			id = *def.ID
+		case strings.IndexByte(term, ':') == -1:
+			// term without IRI mapping is expanded using vocabulary
+			if !c.hasVocab {
+				return fmt.Errorf("%w: term %q has no IRI mapping", ErrInvalidContext, term)
+			}
+			id = c.vocab + term
+		}
+	default:
+		return fmt.Errorf("%w: invalid term definition of %q", ErrInvalidContext, term)
+	}
+
+	if strings.HasPrefix(id, "@") {
+		// keyword aliases are not prefixes
+		delete(c.terms, term)
+		defined[term] = true
+		return nil
+	}
+
+	iri, err := c.expand(id, local, defined)
+	if err != nil {
+		return fmt.Errorf("%w: term %q: %w", ErrInvalidContext, term, err)
+	}
+
+	c.terms[term] = jsonldTerm{
+		iri:    iri,
+		prefix: def.Prefix || (simple && isGenDelimSuffix(iri)),
+	}
+	defined[term] = true
+
+	return nil
+}
+
+// expand IRI mapping of the term, using compact IRIs, terms and vocabulary
+func (c *jsonldContext) expand(v string, local map[string]json.RawMessage, defined map[string]bool) (string, error) {
+	if n := strings.IndexByte(v, ':'); n != -1 {
+		prefix, suffix := v[:n], v[n+1:]
+		if prefix == BlankNode || strings.HasPrefix(suffix, "//") {
+			return v, nil
+		}
+
+		if _, has := local[prefix]; has {
+			if err := c.term(prefix, local, defined); err != nil {
+				return "", err
+			}
+		}
+
+		if t, has := c.terms[prefix]; has && t.prefix {
+			return t.iri + suffix, nil
+		}
+
+		if !isScheme(prefix) {
+			return "", fmt.Errorf("%w: %s", ErrUnknownPrefix, prefix)
+		}
+
+		return v, nil
+	}
+
+	if _, has := local[v]; has {
+		if err := c.term(v, local, defined); err != nil {
+			return "", err
+		}
+	}
+
+	if t, has := c.terms[v]; has {
+		return t.iri, nil
+	}
+
+	if c.hasVocab {
+		return c.vocab + v, nil
+	}
+
+	return "", fmt.Errorf("relative IRI %q", v)
+}
+
+// expand vocabulary mapping, relative vocabulary is resolved against base
+func (c *jsonldContext) expandVocab(v string) (string, error) {
+	if n := strings.IndexByte(v, ':'); n != -1 {
+		if t, has := c.terms[v[:n]]; has && t.prefix {
+			return t.iri + v[n+1:], nil
+		}
+		if isScheme(v[:n]) {
+			return v, nil
+		}
+	}
+
+	if t, has := c.terms[v]; has {
+		return t.iri, nil
+	}
+
+	return c.resolve(v)
+}
+
+// resolve IRI against base
+func (c *jsonldContext) resolve(v string) (string, error) {
+	if n := strings.IndexAny(v, ":/?#"); n > 0 && v[n] == ':' && isScheme(v[:n]) {
+		return v, nil
+	}
+
+	if len(c.base) == 0 {
+		return "", fmt.Errorf("%w: relative IRI %q without @base", ErrInvalidContext, v)
+	}
+
+	return string(ResolveReference(IRI(c.base), v)), nil
+}
@@ -0,0 +1,179 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/fogfish/curie/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestReadContext(t *testing.T) {
+	t.Run("Terms", func(t *testing.T) {
+		ns, err := curie.ReadContext(strings.NewReader(`{
+			"@context": {
+				"@version": 1.1,
+				"foaf": "http://xmlns.com/foaf/0.1/",
+				"name": "foaf:name",
+				"knows": {"@id": "foaf:knows", "@type": "@id"},
+				"ex": {"@id": "https://example.com/id", "@prefix": true},
+				"dc": {"@id": "http://purl.org/dc/terms/"},
+				"id": "@id"
+			}
+		}`))
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(len(ns), 2),
+			it.Equal(ns["foaf"], "http://xmlns.com/foaf/0.1/"),
+			it.Equal(ns["ex"], "https://example.com/id"),
+		)
+	})
+
+	t.Run("CompactIRI", func(t *testing.T) {
+		ns, err := curie.ReadContext(strings.NewReader(`{
+			"schema": "https://schema.org/",
+			"person": "schema:Person/"
+		}`))
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(ns["person"], "https://schema.org/Person/"),
+		)
+	})
+
+	t.Run("Vocab", func(t *testing.T) {
+		ns, err := curie.ReadContext(strings.NewReader(`{
+			"@context": {
+				"@base": "https://example.com/a/b",
+				"@vocab": "../vocab#",
+				"term": "term/"
+			}
+		}`))
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(ns[curie.DefaultPrefix], "https://example.com/vocab#"),
+			it.Equal(ns["term"], "https://example.com/vocab#term/"),
+			it.Equal(curie.URI(ns, "Person"), "https://example.com/vocab#Person"),
+		)
+	})
+
+	t.Run("NestedID", func(t *testing.T) {
+		ns, err := curie.ReadContext(strings.NewReader(`{
+			"@context": {
+				"@vocab": "https://v.example/",
+				"tag": {"@type": "@id", "@prefix": true},
+				"geo": {"@prefix": true, "@context": {"lat": {"@id": "https://geo.example/lat"}}},
+				"none": {"@id": null}
+			}
+		}`))
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(ns["tag"], "https://v.example/tag"),
+			it.Equal(ns["geo"], "https://v.example/geo"),
+		)
+
+		_, has := ns["none"]
+		it.Then(t).ShouldNot(it.True(has))
+	})
+
+	t.Run("Array", func(t *testing.T) {
+		ns, err := curie.ReadContext(strings.NewReader(`{
+			"@context": [
+				{"a": "https://a.example/", "b": "https://b.example/"},
+				null,
+				{"c": "https://c.example/", "@vocab": "https://v.example/"},
+				[{"c": null, "d": "https://d.example/"}]
+			]
+		}`))
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(len(ns), 2),
+			it.Equal(ns["d"], "https://d.example/"),
+			it.Equal(ns[curie.DefaultPrefix], "https://v.example/"),
+		)
+	})
+
+	t.Run("Remote", func(t *testing.T) {
+		_, err := curie.ReadContext(strings.NewReader(`{"@context": "https://schema.org/"}`))
+
+		it.Then(t).Should(
+			it.True(errors.Is(err, curie.ErrInvalidContext)),
+		)
+	})
+
+	t.Run("Cyclic", func(t *testing.T) {
+		_, err := curie.ReadContext(strings.NewReader(`{"a": "b:x", "b": "a:y"}`))
+
+		it.Then(t).Should(
+			it.True(errors.Is(err, curie.ErrInvalidContext)),
+		)
+	})
+
+	t.Run("Relative", func(t *testing.T) {
+		_, err := curie.ReadContext(strings.NewReader(`{"a": "x/"}`))
+
+		it.Then(t).Should(
+			it.True(errors.Is(err, curie.ErrInvalidContext)),
+		)
+	})
+}
+
+func TestWriteContext(t *testing.T) {
+	ns := curie.Namespaces{
+		curie.DefaultPrefix: "https://example.com/",
+		curie.BlankNode:     "_:",
+		"wiki":              "http://en.wikipedia.org/wiki/",
+		"foaf":              "http://xmlns.com/foaf/0.1/",
+		"id":                "https://example.com/id",
+	}
+
+	var b bytes.Buffer
+	err := ns.WriteContext(&b)
+
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(b.String(), `{
+  "@context": {
+    "@vocab": "https://example.com/",
+    "foaf": "http://xmlns.com/foaf/0.1/",
+    "id": {"@id": "https://example.com/id", "@prefix": true},
+    "wiki": "http://en.wikipedia.org/wiki/"
+  }
+}
+`),
+	)
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		delete(ns, curie.BlankNode)
+		rt, err := curie.ReadContext(&b)
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equiv(rt, ns),
+		)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		var b bytes.Buffer
+		err := curie.Namespaces{}.WriteContext(&b)
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(b.String(), "{\n  \"@context\": {}\n}\n"),
+		)
+	})
+}
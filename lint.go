@@ -0,0 +1,182 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Issues reported by Lint, use errors.Is to distinguish them
+var (
+	ErrDuplicateExpansion = errors.New("duplicate expansion")
+	ErrNestedExpansion    = errors.New("nested expansion")
+	ErrNoDelimiter        = errors.New("expansion does not end with / or #")
+	ErrShadowedScheme     = errors.New("prefix shadows URI scheme")
+)
+
+// Severity of the issue
+type Severity int
+
+const (
+	// Warning is the issue, which might cause surprising compaction
+	Warning Severity = iota + 1
+
+	// Error is the issue, which breaks expansion or compaction
+	Error
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Warning:
+		return "warning"
+	case Error:
+		return "error"
+	default:
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+}
+
+// Issue of prefix table
+type Issue struct {
+	Severity Severity
+	Prefix   string
+	IRI      string
+	Err      error
+}
+
+func (i Issue) Error() string {
+	return fmt.Sprintf("%s: prefix %q: %v", i.Severity, i.Prefix, i.Err)
+}
+
+func (i Issue) Unwrap() error { return i.Err }
+
+// schemes commonly used by IRIs, prefixes must not shadow them
+var schemes = map[string]struct{}{
+	"http": {}, "https": {}, "urn": {}, "mailto": {}, "file": {}, "ftp": {},
+	"data": {}, "tag": {}, "tel": {}, "ldap": {}, "ws": {}, "wss": {}, "did": {},
+}
+
+// Lint checks consistency of prefix table, issues are sorted by prefix.
+//
+// Errors:
+//   - prefix is not NCName
+//   - expansion is not absolute IRI or valid URI template
+//   - expansion is shared by multiple prefixes, only one of them is used by compaction
+//
+// Warnings:
+//   - expansion is a prefix of another one
+//   - expansion does not end with `/` or `#`
+//   - prefix shadows well-known URI scheme (e.g. http)
+//
+// The prefixes must be Enumerable, the error is reported otherwise.
+func Lint(prefixes Prefixes) []Issue {
+	ns, ok := namespacesOf(prefixes)
+	if !ok {
+		return []Issue{{Severity: Error, Err: ErrNotEnumerable}}
+	}
+
+	seq := make([]string, 0, len(ns))
+	for prefix := range ns {
+		seq = append(seq, prefix)
+	}
+	sort.Strings(seq)
+
+	issues := make([]Issue, 0)
+	report := func(severity Severity, prefix string, err error) {
+		issues = append(issues, Issue{Severity: severity, Prefix: prefix, IRI: ns[prefix], Err: err})
+	}
+
+	for _, prefix := range seq {
+		iri := ns[prefix]
+
+		if prefix == BlankNode {
+			report(Warning, prefix, fmt.Errorf("%w: blank node prefix is never expanded", ErrInvalidPrefix))
+			continue
+		}
+
+		if prefix != DefaultPrefix && !isNCName(prefix) {
+			report(Error, prefix, fmt.Errorf("%w: %q is not NCName", ErrInvalidPrefix, prefix))
+		}
+
+		if _, has := schemes[strings.ToLower(prefix)]; has {
+			report(Warning, prefix, fmt.Errorf("%w: %s", ErrShadowedScheme, prefix))
+		}
+
+		if isTemplate(iri) {
+			t, err := parseTemplate(iri)
+			if err != nil {
+				report(Error, prefix, err)
+				continue
+			}
+
+			if err := parseAbsolute(t.expand("x")); err != nil {
+				report(Error, prefix, err)
+			}
+			continue
+		}
+
+		if err := parseAbsolute(iri); err != nil {
+			report(Error, prefix, err)
+			continue
+		}
+
+		if !strings.HasSuffix(iri, "/") && !strings.HasSuffix(iri, "#") {
+			report(Warning, prefix, fmt.Errorf("%w: %s", ErrNoDelimiter, iri))
+		}
+
+		// nested expansion is reported once, using preferred prefix of outer IRI
+		nested := map[string]string{}
+		for _, other := range seq {
+			switch {
+			case other == prefix || other == BlankNode:
+			case ns[other] == iri:
+				if preferred(other, prefix) {
+					report(Error, prefix, fmt.Errorf("%w: %s is bound to %s", ErrDuplicateExpansion, iri, other))
+				}
+			case len(ns[other]) > 0 && strings.HasPrefix(iri, ns[other]):
+				if was, has := nested[ns[other]]; !has || preferred(other, was) {
+					nested[ns[other]] = other
+				}
+			}
+		}
+
+		for _, other := range seq {
+			if nested[ns[other]] == other {
+				report(Warning, prefix, fmt.Errorf("%w: %s is nested in %s", ErrNestedExpansion, iri, other))
+			}
+		}
+	}
+
+	return issues
+}
+
+// NewNamespaces creates Namespaces, it fails if the table has errors.
+// See Lint for details.
+func NewNamespaces(ns map[string]string) (Namespaces, error) {
+	seq := make(Namespaces, len(ns))
+	for prefix, iri := range ns {
+		seq[prefix] = iri
+	}
+
+	var errs []error
+	for _, issue := range Lint(seq) {
+		if issue.Severity == Error {
+			errs = append(errs, issue)
+		}
+	}
+
+	if len(errs) != 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return seq, nil
+}
@@ -0,0 +1,95 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fogfish/curie/v2"
+	"github.com/fogfish/curie/v2/vocab"
+	"github.com/fogfish/it/v2"
+)
+
+func TestLint(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		it.Then(t).Should(
+			it.Seq(curie.Lint(vocab.Namespaces())).Equal(),
+		)
+	})
+
+	t.Run("Issues", func(t *testing.T) {
+		issues := curie.Lint(curie.Namespaces{
+			"ex":   "https://example.com/",
+			"ex2":  "https://example.com/",
+			"exa":  "https://example.com/a/",
+			"1x":   "https://example.org/",
+			"rel":  "example/",
+			"id":   "https://example.net/id",
+			"http": "http://example.net/",
+			"_":    "_:",
+		})
+
+		expect := []struct {
+			severity curie.Severity
+			prefix   string
+			err      error
+		}{
+			{curie.Error, "1x", curie.ErrInvalidPrefix},
+			{curie.Warning, "_", curie.ErrInvalidPrefix},
+			{curie.Error, "ex2", curie.ErrDuplicateExpansion},
+			{curie.Warning, "exa", curie.ErrNestedExpansion},
+			{curie.Warning, "http", curie.ErrShadowedScheme},
+			{curie.Warning, "id", curie.ErrNoDelimiter},
+			{curie.Error, "rel", curie.ErrInvalidScheme},
+		}
+
+		it.Then(t).Should(it.Equal(len(issues), len(expect)))
+		for i, issue := range issues {
+			it.Then(t).Should(
+				it.Equal(issue.Severity, expect[i].severity),
+				it.Equal(issue.Prefix, expect[i].prefix),
+				it.True(errors.Is(issue, expect[i].err)),
+			)
+		}
+
+		it.Then(t).Should(
+			it.Equal(issues[3].Err.Error(), "nested expansion: https://example.com/a/ is nested in ex"),
+		)
+	})
+
+	t.Run("NotEnumerable", func(t *testing.T) {
+		issues := curie.Lint(struct{ curie.Prefixes }{vocab.Namespaces()})
+
+		it.Then(t).Should(
+			it.Equal(len(issues), 1),
+			it.True(errors.Is(issues[0], curie.ErrNotEnumerable)),
+		)
+	})
+}
+
+func TestNewNamespaces(t *testing.T) {
+	ns, err1 := curie.NewNamespaces(map[string]string{
+		"ex":  "https://example.com/",
+		"exa": "https://example.com/a/",
+	})
+
+	_, err2 := curie.NewNamespaces(map[string]string{
+		"ex":  "https://example.com/",
+		"ex2": "https://example.com/",
+		"1x":  "https://example.org/",
+	})
+
+	it.Then(t).Should(
+		it.Nil(err1),
+		it.Equal(len(ns), 2),
+		it.True(errors.Is(err2, curie.ErrDuplicateExpansion)),
+		it.True(errors.Is(err2, curie.ErrInvalidPrefix)),
+	)
+}
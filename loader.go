@@ -0,0 +1,202 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// LoaderOption configures the loader
+type LoaderOption func(*Loader)
+
+// WithPollInterval defines how often the file is checked for changes,
+// the interval must be positive
+func WithPollInterval(d time.Duration) LoaderOption {
+	return func(l *Loader) { l.interval = d }
+}
+
+// WithDecoder defines the format of the file, JSON object is used by default
+//
+//	{"prefix": "https://example.com/"}
+func WithDecoder(f func(io.Reader) (Namespaces, error)) LoaderOption {
+	return func(l *Loader) { l.decoder = f }
+}
+
+// WithValidator defines validation of the new version before it is swapped in,
+// the version is rejected if Lint reports errors by default.
+func WithValidator(f func(Namespaces) error) LoaderOption {
+	return func(l *Loader) { l.validator = f }
+}
+
+// WithErrorHandler receives failures of background reloads,
+// the previous version of prefixes is kept on failure
+func WithErrorHandler(f func(error)) LoaderOption {
+	return func(l *Loader) { l.onError = f }
+}
+
+// Loader watches the file with prefixes and reloads it on changes. The file is
+// polled, the new version is validated before it is atomically swapped in.
+// Each version is a Snapshot, use it for consistent view of prefixes across
+// multiple operations.
+//
+//	prefixes, err := curie.NewLoader("prefixes.json")
+//	go prefixes.Run(ctx)
+//
+//	curie.URI(prefixes, iri)
+type Loader struct {
+	path      string
+	interval  time.Duration
+	decoder   func(io.Reader) (Namespaces, error)
+	validator func(Namespaces) error
+	onError   func(error)
+
+	mu       sync.Mutex
+	modTime  time.Time
+	size     int64
+	checksum [sha256.Size]byte
+	snapshot atomic.Pointer[Snapshot]
+}
+
+var _ Prefixes = (*Loader)(nil)
+
+// NewLoader creates loader and reads the initial version of the file
+func NewLoader(path string, opts ...LoaderOption) (*Loader, error) {
+	l := &Loader{
+		path:      path,
+		interval:  5 * time.Second,
+		decoder:   decodeNamespaces,
+		validator: lintNamespaces,
+		onError:   func(error) {},
+	}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	if l.interval <= 0 {
+		return nil, fmt.Errorf("curie: invalid poll interval %s", l.interval)
+	}
+
+	if _, err := l.Reload(); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// Snapshot returns current version of prefixes
+func (l *Loader) Snapshot() *Snapshot { return l.snapshot.Load() }
+
+// Create new URI using current version of prefixes
+func (l *Loader) Create(uri string) IRI { return l.snapshot.Load().Create(uri) }
+
+// Lookup prefix in current version of prefixes
+func (l *Loader) Lookup(prefix string) (string, bool) {
+	return l.snapshot.Load().Lookup(prefix)
+}
+
+// Range iterates over current version of prefixes
+func (l *Loader) Range(f func(prefix, iri string) bool) {
+	l.snapshot.Load().Range(f)
+}
+
+// template of current version
+func (l *Loader) template(prefix, iri string) (*uriTemplate, bool) {
+	return l.snapshot.Load().template(prefix, iri)
+}
+
+// Run polls the file until context is cancelled
+func (l *Loader) Run(ctx context.Context) {
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := l.Reload(); err != nil {
+				l.onError(err)
+			}
+		}
+	}
+}
+
+// Reload reads the file if it is changed, returns true if new version is
+// swapped in. The current version is kept if the file is invalid, the failure
+// is reported once, the file is not read again until it is changed.
+func (l *Loader) Reload() (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	fi, err := os.Stat(l.path)
+	if err != nil {
+		return false, err
+	}
+
+	current := l.snapshot.Load()
+	if current != nil && fi.ModTime().Equal(l.modTime) && fi.Size() == l.size {
+		return false, nil
+	}
+
+	b, err := os.ReadFile(l.path)
+	if err != nil {
+		return false, err
+	}
+
+	checksum := sha256.Sum256(b)
+	if current != nil && checksum == l.checksum {
+		l.modTime, l.size = fi.ModTime(), fi.Size()
+		return false, nil
+	}
+
+	ns, err := l.decoder(bytes.NewReader(b))
+	if err != nil {
+		l.modTime, l.size, l.checksum = fi.ModTime(), fi.Size(), checksum
+		return false, fmt.Errorf("curie: failed to decode %s: %w", l.path, err)
+	}
+
+	if err := l.validator(ns); err != nil {
+		l.modTime, l.size, l.checksum = fi.ModTime(), fi.Size(), checksum
+		return false, fmt.Errorf("curie: invalid %s: %w", l.path, err)
+	}
+
+	version := uint64(1)
+	if current != nil {
+		version = current.Version + 1
+	}
+
+	l.snapshot.Store(&Snapshot{Trie: NewTrie(ns), Version: version})
+	l.modTime, l.size, l.checksum = fi.ModTime(), fi.Size(), checksum
+
+	return true, nil
+}
+
+func decodeNamespaces(r io.Reader) (Namespaces, error) {
+	var ns Namespaces
+	if err := json.NewDecoder(r).Decode(&ns); err != nil {
+		return nil, err
+	}
+
+	return ns, nil
+}
+
+func lintNamespaces(ns Namespaces) error {
+	_, err := NewNamespaces(ns)
+	return err
+}
@@ -0,0 +1,178 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fogfish/curie/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func writePrefixes(t *testing.T, file, content string) {
+	t.Helper()
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// make sure modification time is changed
+	ts := time.Now().Add(time.Duration(len(content)) * time.Second)
+	if err := os.Chtimes(file, ts, ts); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoader(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "prefixes.json")
+	writePrefixes(t, file, `{"ex": "https://example.com/"}`)
+
+	prefixes, err := curie.NewLoader(file)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(prefixes.Snapshot().Version, 1),
+		it.Equal(curie.URI(prefixes, "ex:a"), "https://example.com/a"),
+	)
+
+	t.Run("Unchanged", func(t *testing.T) {
+		ok, err := prefixes.Reload()
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.True(!ok),
+			it.Equal(prefixes.Snapshot().Version, 1),
+		)
+	})
+
+	t.Run("Changed", func(t *testing.T) {
+		writePrefixes(t, file, `{"ex": "https://example.org/", "wiki": "http://en.wikipedia.org/wiki/"}`)
+		ok, err := prefixes.Reload()
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.True(ok),
+			it.Equal(prefixes.Snapshot().Version, 2),
+			it.Equal(curie.URI(prefixes, "ex:a"), "https://example.org/a"),
+			it.Equal(curie.FromURI(prefixes, "http://en.wikipedia.org/wiki/CURIE"), "wiki:CURIE"),
+		)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		writePrefixes(t, file, `{"1ex": "https://example.net/"}`)
+		ok, err := prefixes.Reload()
+
+		it.Then(t).Should(
+			it.True(errors.Is(err, curie.ErrInvalidPrefix)),
+			it.True(!ok),
+			it.Equal(prefixes.Snapshot().Version, 2),
+			it.Equal(curie.URI(prefixes, "ex:a"), "https://example.org/a"),
+		)
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		writePrefixes(t, file, `{"ex": `)
+		ok, err := prefixes.Reload()
+
+		it.Then(t).Should(
+			it.True(err != nil),
+			it.True(!ok),
+			it.Equal(prefixes.Snapshot().Version, 2),
+		)
+	})
+
+	t.Run("Unchanged Malformed", func(t *testing.T) {
+		ok, err := prefixes.Reload()
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.True(!ok),
+			it.Equal(prefixes.Snapshot().Version, 2),
+		)
+	})
+
+	t.Run("Recovered", func(t *testing.T) {
+		writePrefixes(t, file, `{"ex": "https://example.org/", "wiki": "http://en.wikipedia.org/wiki/"}`)
+		ok, err := prefixes.Reload()
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.True(ok),
+			it.Equal(prefixes.Snapshot().Version, 3),
+		)
+	})
+}
+
+func TestLoaderFail(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "prefixes.json")
+
+	_, err1 := curie.NewLoader(file)
+
+	writePrefixes(t, file, `{"ex": "example"}`)
+	_, err2 := curie.NewLoader(file)
+
+	writePrefixes(t, file, `{"ex": "https://example.com/"}`)
+	_, err3 := curie.NewLoader(file, curie.WithPollInterval(0))
+	_, err4 := curie.NewLoader(file, curie.WithPollInterval(-time.Second))
+
+	it.Then(t).Should(
+		it.True(errors.Is(err1, os.ErrNotExist)),
+		it.True(errors.Is(err2, curie.ErrInvalidScheme)),
+	).ShouldNot(
+		it.Nil(err3),
+		it.Nil(err4),
+	)
+}
+
+func TestLoaderRun(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "prefixes.json")
+	writePrefixes(t, file, `{"ex": "https://example.com/"}`)
+
+	failures := make(chan error, 10)
+	prefixes, err := curie.NewLoader(file,
+		curie.WithPollInterval(5*time.Millisecond),
+		curie.WithErrorHandler(func(err error) {
+			select {
+			case failures <- err:
+			default:
+			}
+		}),
+	)
+	it.Then(t).Should(it.Nil(err))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go prefixes.Run(ctx)
+
+	writePrefixes(t, file, `{"ex": "https://example.org/"}`)
+	for i := 0; i < 200 && prefixes.Snapshot().Version == 1; i++ {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	it.Then(t).Should(
+		it.Equal(prefixes.Snapshot().Version, 2),
+		it.Equal(curie.URI(prefixes, "ex:a"), "https://example.org/a"),
+	)
+
+	writePrefixes(t, file, `{"ex": 1}`)
+	select {
+	case err := <-failures:
+		it.Then(t).ShouldNot(it.Nil(err))
+	case <-time.After(time.Second):
+		t.Fatal("reload failure is not reported")
+	}
+
+	// invalid file is not read again until it is changed
+	time.Sleep(50 * time.Millisecond)
+	it.Then(t).Should(
+		it.Equal(len(failures), 0),
+	)
+}
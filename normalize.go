@@ -0,0 +1,224 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fogfish/curie/v2/internal/reference"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalization is the level of syntax-based normalization ladder,
+// as defined by RFC 3987 section 5.3.2. Levels are combined as flags.
+type Normalization uint
+
+const (
+	// Case normalization, upper case hexadecimal digits of percent-encoding
+	// and lower case scheme and host (RFC 3987 section 5.3.2.1)
+	NormalizeCase Normalization = 1 << iota
+
+	// Character normalization, Unicode NFC (RFC 3987 section 5.3.2.2)
+	NormalizeCharacter
+
+	// Percent-encoding normalization, decodes percent-encoded unreserved
+	// characters (RFC 3987 section 5.3.2.3)
+	NormalizePercentEncoding
+
+	// Path segment normalization, removes dot-segments from path
+	// (RFC 3987 section 5.3.2.4)
+	NormalizePathSegment
+
+	// All steps of the ladder
+	NormalizeAll = NormalizeCase | NormalizeCharacter | NormalizePercentEncoding | NormalizePathSegment
+)
+
+// Normalize CURIE reference using syntax-based normalization ladder.
+// The prefix is NCName, it is case sensitive and never changed.
+// All levels are applied if none is given.
+//
+//	a:%7euser/./b/../%e1%bf%ac ⟼ a:~user/Ῥ
+func Normalize(iri IRI, level ...Normalization) IRI {
+	if len(iri) == 0 {
+		return Empty
+	}
+
+	n := strings.IndexByte(string(iri), ':')
+	if n == -1 {
+		return IRI(normalize(string(iri), levelOf(level), true))
+	}
+
+	return IRI(string(iri)[:n+1] + normalize(string(iri)[n+1:], levelOf(level), true))
+}
+
+// NormalizeURI normalizes URI, the expanded form of CURIE produced by URI,
+// using syntax-based normalization ladder. All levels are applied if none is given.
+//
+//	HTTP://Example.COM/%7euser/./b/../c ⟼ http://example.com/~user/c
+func NormalizeURI(uri string, level ...Normalization) string {
+	lvl := levelOf(level)
+
+	n := strings.IndexByte(uri, ':')
+	if n == -1 || !isScheme(uri[:n]) {
+		return normalize(uri, lvl, false)
+	}
+
+	scheme := uri[:n]
+	if lvl&NormalizeCase != 0 {
+		scheme = strings.ToLower(scheme)
+	}
+
+	return scheme + ":" + normalize(uri[n+1:], lvl, false)
+}
+
+func levelOf(level []Normalization) Normalization {
+	if len(level) == 0 {
+		return NormalizeAll
+	}
+
+	var lvl Normalization
+	for _, x := range level {
+		lvl |= x
+	}
+	return lvl
+}
+
+// normalize reference (hier-part, query and fragment)
+func normalize(ref string, lvl Normalization, iri bool) string {
+	if lvl&NormalizePercentEncoding != 0 {
+		ref = normalizePercentEncoding(ref, iri)
+	}
+
+	if lvl&NormalizeCase != 0 {
+		ref = normalizeCase(ref)
+	}
+
+	if lvl&NormalizeCharacter != 0 && iri {
+		ref = norm.NFC.String(ref)
+	}
+
+	if lvl&NormalizePathSegment != 0 {
+		ref = normalizePathSegment(ref)
+	}
+
+	return ref
+}
+
+// upper case percent-encoding and lower case host
+func normalizeCase(ref string) string {
+	b := []byte(ref)
+	for i := 0; i < len(b)-2; i++ {
+		if b[i] == '%' && ishex(b[i+1]) && ishex(b[i+2]) {
+			b[i+1] = upperhex[unhex(b[i+1])]
+			b[i+2] = upperhex[unhex(b[i+2])]
+			i += 2
+		}
+	}
+
+	if len(b) > 2 && b[0] == '/' && b[1] == '/' {
+		end := 2 + strings.IndexAny(string(b[2:])+"/", "/?#")
+		host := 2 + strings.LastIndexByte(string(b[2:end]), '@') + 1
+		port := strings.LastIndexByte(string(b[host:end]), ':')
+		if port != -1 && strings.IndexByte(string(b[host+port:end]), ']') == -1 {
+			end = host + port
+		}
+		copy(b[host:end], strings.ToLower(string(b[host:end])))
+	}
+
+	return string(b)
+}
+
+// decode percent-encoded unreserved characters, non-ASCII characters are
+// decoded only for IRIs
+func normalizePercentEncoding(ref string, iri bool) string {
+	if strings.IndexByte(ref, '%') == -1 {
+		return ref
+	}
+
+	b := make([]byte, 0, len(ref))
+	for i := 0; i < len(ref); {
+		if ref[i] != '%' || i+2 >= len(ref) || !ishex(ref[i+1]) || !ishex(ref[i+2]) {
+			b = append(b, ref[i])
+			i++
+			continue
+		}
+
+		// collect percent-encoded UTF-8 sequence of the character
+		var seq [utf8.UTFMax]byte
+		n, j := 0, i
+		for n < utf8.UTFMax && j+2 < len(ref) && ref[j] == '%' && ishex(ref[j+1]) && ishex(ref[j+2]) {
+			seq[n] = unhex(ref[j+1])<<4 | unhex(ref[j+2])
+			n++
+			j += 3
+			if utf8.FullRune(seq[:n]) {
+				break
+			}
+		}
+
+		r, size := utf8.DecodeRune(seq[:n])
+		switch {
+		case r == utf8.RuneError && size <= 1:
+			b = append(b, ref[i:i+3]...)
+			i += 3
+		case r < utf8.RuneSelf && isIUnreserved(r):
+			b = append(b, byte(r))
+			i += 3
+		case r >= utf8.RuneSelf && iri && isIUnreserved(r):
+			b = utf8.AppendRune(b, r)
+			i += 3 * size
+		default:
+			b = append(b, ref[i:i+3*size]...)
+			i += 3 * size
+		}
+	}
+
+	return string(b)
+}
+
+// remove dot-segments from path component
+func normalizePathSegment(ref string) string {
+	at := 0
+	if strings.HasPrefix(ref, "//") {
+		at = 2 + strings.IndexAny(ref[2:]+"/", "/?#")
+	}
+
+	end := at + strings.IndexAny(ref[at:]+"?", "?#")
+	path := ref[at:end]
+
+	switch {
+	case len(path) == 0:
+		return ref
+	case path[0] == '/':
+		path = reference.RemoveDotSegments(path)
+	case !climbs(path):
+		// relative path is normalized as it is rooted at the prefix
+		path = reference.RemoveDotSegments("/" + path)[1:]
+	}
+
+	return ref[:at] + path + ref[end:]
+}
+
+// checks if relative path climbs above its root
+func climbs(path string) bool {
+	depth := 0
+	for _, seg := range strings.Split(path, "/") {
+		switch seg {
+		case ".", "":
+		case "..":
+			depth--
+			if depth < 0 {
+				return true
+			}
+		default:
+			depth++
+		}
+	}
+	return false
+}
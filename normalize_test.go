@@ -0,0 +1,85 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/fogfish/curie/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestNormalize(t *testing.T) {
+	for input, expected := range map[curie.IRI]curie.IRI{
+		"":                          "",
+		"a:b":                       "a:b",
+		"A:b":                       "A:b",
+		"a:%7euser":                 "a:~user",
+		"a:%2f%3a":                  "a:%2F%3A",
+		"a:%e1%bf%ac%CF%8C":         "a:Ῥό",
+		"a:%e1%bf":                  "a:%E1%BF",
+		"a:%20":                     "a:%20",
+		"a:b/./c/../d":              "a:b/d",
+		"a:../b/./c":                "a:../b/./c",
+		"a:/b/../../c":              "a:/c",
+		"a:b/c/..":                  "a:b/",
+		"a:b/c?x=./..#../y":         "a:b/c?x=./..#../y",
+		"a://User@Example.COM:80/P": "a://User@example.com:80/P",
+		"a:e\u0301":                 "a:\u00e9",
+		"b/./c":                     "b/c",
+	} {
+		t.Run(fmt.Sprintf("(%s)", input), func(t *testing.T) {
+			it.Then(t).Should(
+				it.Equal(curie.Normalize(input), expected),
+			)
+		})
+	}
+}
+
+func TestNormalizeLevel(t *testing.T) {
+	iri := curie.IRI("a:%7e/%e1%bf%ac/./e\u0301")
+
+	it.Then(t).Should(
+		it.Equal(curie.Normalize(iri, curie.NormalizeCase), "a:%7E/%E1%BF%AC/./e\u0301"),
+		it.Equal(curie.Normalize(iri, curie.NormalizePercentEncoding), "a:~/Ῥ/./e\u0301"),
+		it.Equal(curie.Normalize(iri, curie.NormalizePathSegment), "a:%7e/%e1%bf%ac/e\u0301"),
+		it.Equal(curie.Normalize(iri, curie.NormalizeCharacter), "a:%7e/%e1%bf%ac/./\u00e9"),
+		it.Equal(curie.Normalize(iri, curie.NormalizeCase, curie.NormalizePathSegment), "a:%7E/%E1%BF%AC/e\u0301"),
+		it.Equal(curie.Normalize(iri), curie.Normalize(iri, curie.NormalizeAll)),
+	)
+}
+
+func TestNormalizeURI(t *testing.T) {
+	for input, expected := range map[string]string{
+		"":                                    "",
+		"HTTP://Example.COM/%7euser/./b/../c": "http://example.com/~user/c",
+		"http://example.com/%e1%bf%ac":        "http://example.com/%E1%BF%AC",
+		"http://[::1]:8080/a":                 "http://[::1]:8080/a",
+		"https://example.com/a?q=%41#%2f":     "https://example.com/a?q=A#%2F",
+		"urn:isbn:%30451450523":               "urn:isbn:0451450523",
+	} {
+		t.Run(fmt.Sprintf("(%s)", input), func(t *testing.T) {
+			it.Then(t).Should(
+				it.Equal(curie.NormalizeURI(input), expected),
+			)
+		})
+	}
+
+	t.Run("Expanded", func(t *testing.T) {
+		prefixes := curie.Namespaces{"a": "https://example.com/"}
+
+		it.Then(t).Should(
+			it.Equal(
+				curie.NormalizeURI(curie.URI(prefixes, "a:%7e/./Ῥ")),
+				curie.URI(prefixes, curie.Normalize("a:~/Ῥ")),
+			),
+		)
+	})
+}
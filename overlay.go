@@ -0,0 +1,145 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie
+
+import "context"
+
+// Overlay combines layers of prefixes (e.g. per-request, tenant and global),
+// the first layer has the highest precedence.
+//
+// Lookup uses the first layer defining the prefix. Create uses the first
+// layer compacting URI, the compaction is skipped if the prefix is shadowed
+// by the upper layer, so that the result is always expanded to the same URI.
+//
+//	prefixes := curie.Overlay(request, tenant, global)
+func Overlay(layers ...Prefixes) Prefixes {
+	seq := make(overlay, 0, len(layers))
+	for _, layer := range layers {
+		switch v := layer.(type) {
+		case nil:
+		case overlay:
+			seq = append(seq, v...)
+		default:
+			seq = append(seq, v)
+		}
+	}
+
+	return seq
+}
+
+type overlay []Prefixes
+
+func (seq overlay) Create(uri string) IRI {
+	for _, layer := range seq {
+		iri := layer.Create(uri)
+		if string(iri) == uri {
+			continue
+		}
+
+		schema := Schema(iri)
+		expect, _ := layer.Lookup(schema)
+		if actual, _ := seq.Lookup(schema); actual == expect {
+			return iri
+		}
+	}
+
+	return IRI(uri)
+}
+
+func (seq overlay) Lookup(prefix string) (string, bool) {
+	for _, layer := range seq {
+		if iri, exists := layer.Lookup(prefix); exists {
+			return iri, true
+		}
+	}
+
+	return "", false
+}
+
+// template of the layer which defines the prefix
+func (seq overlay) template(prefix, iri string) (*uriTemplate, bool) {
+	for _, layer := range seq {
+		if _, exists := layer.Lookup(prefix); exists {
+			if p, ok := layer.(templated); ok {
+				return p.template(prefix, iri)
+			}
+			return nil, false
+		}
+	}
+
+	return nil, false
+}
+
+// Range iterates over visible prefixes of enumerable layers
+func (seq overlay) Range(f func(prefix, iri string) bool) {
+	seen := map[string]struct{}{}
+	for _, layer := range seq {
+		if _, exists := layer.(Enumerable); !exists {
+			continue
+		}
+
+		next := true
+		layer.(Enumerable).Range(func(prefix, iri string) bool {
+			if _, has := seen[prefix]; has {
+				return true
+			}
+			if visible, _ := seq.Lookup(prefix); visible != iri {
+				return true
+			}
+			seen[prefix] = struct{}{}
+			next = f(prefix, iri)
+			return next
+		})
+
+		if !next {
+			return
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+
+type prefixesKey struct{}
+
+// WithPrefixes returns context with prefixes, they overlay prefixes
+// already defined by the context.
+//
+//	ctx = curie.WithPrefixes(ctx, global)
+//	ctx = curie.WithPrefixes(ctx, tenant)
+func WithPrefixes(ctx context.Context, prefixes Prefixes) context.Context {
+	if prefixes == nil {
+		return ctx
+	}
+
+	if scope, exists := ctx.Value(prefixesKey{}).(Prefixes); exists {
+		prefixes = Overlay(prefixes, scope)
+	}
+
+	return context.WithValue(ctx, prefixesKey{}, prefixes)
+}
+
+// PrefixesFrom returns prefixes defined by the context,
+// empty Namespaces are returned if none is defined.
+func PrefixesFrom(ctx context.Context) Prefixes {
+	if scope, exists := ctx.Value(prefixesKey{}).(Prefixes); exists {
+		return scope
+	}
+
+	return Namespaces{}
+}
+
+// URIContext converts CURIE to fully qualified URL using prefixes of the context
+func URIContext(ctx context.Context, iri IRI) string {
+	return URI(PrefixesFrom(ctx), iri)
+}
+
+// FromURIContext converts fully qualified URL to CURIE using prefixes of the context
+func FromURIContext(ctx context.Context, uri string) IRI {
+	return FromURI(PrefixesFrom(ctx), uri)
+}
@@ -0,0 +1,100 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fogfish/curie/v2"
+	"github.com/fogfish/it/v2"
+)
+
+var (
+	global = curie.Namespaces{
+		"ex":   "https://example.com/",
+		"wiki": "http://en.wikipedia.org/wiki/",
+	}
+	tenant = curie.Namespaces{
+		"ex":  "https://tenant.example.com/",
+		"doc": "https://tenant.example.com/doc/",
+	}
+)
+
+func TestOverlay(t *testing.T) {
+	prefixes := curie.Overlay(tenant, nil, global)
+
+	t.Run("Lookup", func(t *testing.T) {
+		uri, has := prefixes.Lookup("ex")
+
+		it.Then(t).Should(
+			it.True(has),
+			it.Equal(uri, "https://tenant.example.com/"),
+			it.Equal(curie.URI(prefixes, "wiki:CURIE"), "http://en.wikipedia.org/wiki/CURIE"),
+		)
+	})
+
+	t.Run("Create", func(t *testing.T) {
+		it.Then(t).Should(
+			it.Equal(curie.FromURI(prefixes, "https://tenant.example.com/a"), "ex:a"),
+			it.Equal(curie.FromURI(prefixes, "https://tenant.example.com/doc/a"), "doc:a"),
+			it.Equal(curie.FromURI(prefixes, "http://en.wikipedia.org/wiki/CURIE"), "wiki:CURIE"),
+			// global ex: is shadowed by the tenant
+			it.Equal(curie.FromURI(prefixes, "https://example.com/a"), "https://example.com/a"),
+		)
+	})
+
+	t.Run("Range", func(t *testing.T) {
+		ns := curie.Namespaces{}
+		prefixes.(curie.Enumerable).Range(func(prefix, iri string) bool {
+			ns[prefix] = iri
+			return true
+		})
+
+		it.Then(t).Should(
+			it.Equiv(ns, curie.Namespaces{
+				"ex":   "https://tenant.example.com/",
+				"doc":  "https://tenant.example.com/doc/",
+				"wiki": "http://en.wikipedia.org/wiki/",
+			}),
+		)
+	})
+}
+
+func TestPrefixesContext(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Empty", func(t *testing.T) {
+		it.Then(t).Should(
+			it.Equal(curie.URIContext(ctx, "ex:a"), "ex:a"),
+			it.Equal(curie.FromURIContext(ctx, "https://example.com/a"), "https://example.com/a"),
+		)
+	})
+
+	ctx = curie.WithPrefixes(ctx, global)
+	ctx = curie.WithPrefixes(ctx, tenant)
+	ctx = curie.WithPrefixes(ctx, nil)
+
+	t.Run("Scoped", func(t *testing.T) {
+		it.Then(t).Should(
+			it.Equal(curie.URIContext(ctx, "ex:a"), "https://tenant.example.com/a"),
+			it.Equal(curie.URIContext(ctx, "wiki:CURIE"), "http://en.wikipedia.org/wiki/CURIE"),
+			it.Equal(curie.FromURIContext(ctx, "https://tenant.example.com/doc/a"), "doc:a"),
+		)
+	})
+
+	t.Run("Request", func(t *testing.T) {
+		ctx := curie.WithPrefixes(ctx, curie.Namespaces{"ex": "https://request.example.com/"})
+
+		it.Then(t).Should(
+			it.Equal(curie.URIContext(ctx, "ex:a"), "https://request.example.com/a"),
+			it.Equal(curie.URIContext(ctx, "doc:a"), "https://tenant.example.com/doc/a"),
+		)
+	})
+}
@@ -0,0 +1,396 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+//------------------------------------------------------------------------------
+//
+// Errors
+//
+//------------------------------------------------------------------------------
+
+// Errors returned by the parser, use errors.Is to distinguish them
+var (
+	ErrInvalidPrefix    = errors.New("invalid prefix")
+	ErrInvalidReference = errors.New("invalid reference")
+	ErrTooLong          = errors.New("too long")
+	ErrTooManySegments  = errors.New("too many segments")
+	ErrInvalidSafeCURIE = errors.New("invalid safe CURIE")
+	ErrInvalidScheme    = errors.New("invalid scheme")
+)
+
+// SyntaxError describes malformed CURIE and position of the failure
+type SyntaxError struct {
+	Input  string // input given to the parser
+	Offset int    // byte offset of the failure within the input
+	Reason string // human readable reason
+	Err    error  // one of ErrInvalidPrefix, ErrInvalidReference, ...
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("curie: %s %q at offset %d: %s", e.Err, e.Input, e.Offset, e.Reason)
+}
+
+func (e *SyntaxError) Unwrap() error { return e.Err }
+
+//------------------------------------------------------------------------------
+//
+// Parser
+//
+//------------------------------------------------------------------------------
+
+// ParseOption configures the parser
+type ParseOption func(*parser)
+
+// WithMaxLength limits the length of CURIE in bytes
+func WithMaxLength(n int) ParseOption {
+	return func(p *parser) { p.maxLength = n }
+}
+
+// WithMaxSegments limits the number of path segments in CURIE reference
+func WithMaxSegments(n int) ParseOption {
+	return func(p *parser) { p.maxSegments = n }
+}
+
+// declared relaxes the reference of CURIE with declared prefix. Such CURIE
+// abbreviates absolute IRI, its reference is validated as ihier-part so that
+// the first segment might contain colon (e.g. wiki:Talk:CURIE).
+func declared() ParseOption {
+	return func(p *parser) { p.absolute = true }
+}
+
+type parser struct {
+	input       string
+	maxLength   int
+	maxSegments int
+	absolute    bool
+}
+
+// Parse validates the string and transforms it to IRI.
+// The prefix is validated as NCName, the reference as irelative-ref
+// (RFC 3987). The failure is reported as *SyntaxError.
+//
+//	curie       :=   [ [ prefix ] ':' ] reference
+//	prefix      :=   NCName
+//	reference   :=   irelative-ref (as defined in IRI, RFC 3987)
+func Parse(s string, opts ...ParseOption) (IRI, error) {
+	p := parser{input: s}
+	for _, opt := range opts {
+		opt(&p)
+	}
+
+	if err := p.parse(); err != nil {
+		return Empty, err
+	}
+
+	return IRI(s), nil
+}
+
+// ParseSafe validates the safe CURIE and transforms it to IRI.
+//
+//	safe_curie  :=   '[' curie ']'
+func ParseSafe(s string, opts ...ParseOption) (IRI, error) {
+	if len(s) < 2 || s[0] != '[' || s[len(s)-1] != ']' {
+		return Empty, &SyntaxError{
+			Input:  s,
+			Offset: 0,
+			Reason: "expected [curie]",
+			Err:    ErrInvalidSafeCURIE,
+		}
+	}
+
+	iri, err := Parse(s[1:len(s)-1], opts...)
+	if err != nil {
+		if e, ok := err.(*SyntaxError); ok {
+			e.Input = s
+			e.Offset++
+		}
+		return Empty, err
+	}
+
+	return iri, nil
+}
+
+// parseAbsolute validates IRI with scheme
+//
+//	IRI = scheme ":" ihier-part [ "?" iquery ] [ "#" ifragment ]
+func parseAbsolute(s string, opts ...ParseOption) error {
+	p := parser{input: s, absolute: true}
+	for _, opt := range opts {
+		opt(&p)
+	}
+
+	if p.maxLength > 0 && len(s) > p.maxLength {
+		return p.fail(p.maxLength, ErrTooLong, "exceeds %d bytes", p.maxLength)
+	}
+
+	n := strings.IndexByte(s, ':')
+	if n < 1 || !isScheme(s[:n]) {
+		return p.fail(0, ErrInvalidScheme, "scheme is not valid")
+	}
+
+	return p.reference(n + 1)
+}
+
+func (p *parser) fail(at int, err error, reason string, args ...any) error {
+	return &SyntaxError{
+		Input:  p.input,
+		Offset: at,
+		Reason: fmt.Sprintf(reason, args...),
+		Err:    err,
+	}
+}
+
+func (p *parser) parse() error {
+	if p.maxLength > 0 && len(p.input) > p.maxLength {
+		return p.fail(p.maxLength, ErrTooLong, "exceeds %d bytes", p.maxLength)
+	}
+
+	if len(p.input) == 0 {
+		return nil
+	}
+
+	n := strings.IndexByte(p.input, ':')
+	if n == -1 {
+		return p.reference(0)
+	}
+
+	if err := p.prefix(n); err != nil {
+		return err
+	}
+
+	return p.reference(n + 1)
+}
+
+// prefix := NCName
+func (p *parser) prefix(end int) error {
+	if end == 0 {
+		return nil
+	}
+
+	if at, ok := ncname(p.input[:end]); !ok {
+		return p.fail(at, ErrInvalidPrefix, "prefix is not NCName")
+	}
+
+	return nil
+}
+
+// reference validates irelative-ref
+//
+//	irelative-ref  = irelative-part [ "?" iquery ] [ "#" ifragment ]
+//	irelative-part = "//" iauthority ipath-abempty
+//	               / ipath-absolute
+//	               / ipath-noscheme
+//	               / ipath-empty
+func (p *parser) reference(at int) error {
+	s := p.input
+	i := at
+
+	if strings.HasPrefix(s[i:], "//") {
+		i += 2
+		end := i + strings.IndexAny(s[i:]+"/", "/?#")
+		if err := p.scan(i, end, isAuthority, "authority"); err != nil {
+			return err
+		}
+		i = end
+	}
+
+	end := i + strings.IndexAny(s[i:]+"?", "?#")
+	if err := p.path(at, i, end); err != nil {
+		return err
+	}
+	i = end
+
+	if i < len(s) && s[i] == '?' {
+		i++
+		end := i + strings.IndexAny(s[i:]+"#", "#")
+		if err := p.scan(i, end, isQuery, "query"); err != nil {
+			return err
+		}
+		i = end
+	}
+
+	if i < len(s) && s[i] == '#' {
+		i++
+		if err := p.scan(i, len(s), isFragment, "fragment"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *parser) path(ref, at, end int) error {
+	if err := p.scan(at, end, isPath, "path"); err != nil {
+		return err
+	}
+
+	// ipath-noscheme: the first segment of relative path cannot contain colon
+	if !p.absolute && at == ref && at < end && p.input[at] != '/' {
+		seg := at + strings.IndexByte(p.input[at:end]+"/", '/')
+		if x := strings.IndexByte(p.input[at:seg], ':'); x != -1 {
+			return p.fail(at+x, ErrInvalidReference, "colon in the first path segment")
+		}
+	}
+
+	if p.maxSegments > 0 {
+		seq := 0
+		for i := at; i < end; i++ {
+			if i == at && p.input[i] == '/' {
+				continue
+			}
+			if i == at || p.input[i-1] == '/' {
+				seq++
+				if seq > p.maxSegments {
+					return p.fail(i, ErrTooManySegments, "exceeds %d segments", p.maxSegments)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// scan validates characters of the component, including percent-encoding
+func (p *parser) scan(at, end int, valid func(rune) bool, component string) error {
+	s := p.input
+	for i := at; i < end; {
+		if s[i] == '%' {
+			if i+2 >= end || !ishex(s[i+1]) || !ishex(s[i+2]) {
+				return p.fail(i, ErrInvalidReference, "malformed percent-encoding in %s", component)
+			}
+			i += 3
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size <= 1 {
+			return p.fail(i, ErrInvalidReference, "invalid UTF-8 in %s", component)
+		}
+
+		if !valid(r) {
+			return p.fail(i, ErrInvalidReference, "illegal character %q in %s", r, component)
+		}
+		i += size
+	}
+
+	return nil
+}
+
+//------------------------------------------------------------------------------
+//
+// Character classes
+//
+//------------------------------------------------------------------------------
+
+// ncname validates NCName, returns offset of first illegal rune
+//
+// NCName ::= NameStartChar (NameChar - ':')*
+func ncname(s string) (int, bool) {
+	if len(s) == 0 {
+		return 0, false
+	}
+
+	for i, r := range s {
+		if r == utf8.RuneError {
+			return i, false
+		}
+
+		if i == 0 && !isNameStartChar(r) {
+			return i, false
+		}
+
+		if !isNameChar(r) {
+			return i, false
+		}
+	}
+
+	return 0, true
+}
+
+func isNCName(s string) bool {
+	_, ok := ncname(s)
+	return ok
+}
+
+// scheme = ALPHA *( ALPHA / DIGIT / "+" / "-" / "." )
+func isScheme(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z'):
+		case i > 0 && (('0' <= c && c <= '9') || c == '+' || c == '-' || c == '.'):
+		default:
+			return false
+		}
+	}
+
+	return len(s) > 0
+}
+
+func isNameStartChar(r rune) bool {
+	return ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || r == '_' ||
+		(0xC0 <= r && r <= 0xD6) || (0xD8 <= r && r <= 0xF6) ||
+		(0xF8 <= r && r <= 0x2FF) || (0x370 <= r && r <= 0x37D) ||
+		(0x37F <= r && r <= 0x1FFF) || (0x200C <= r && r <= 0x200D) ||
+		(0x2070 <= r && r <= 0x218F) || (0x2C00 <= r && r <= 0x2FEF) ||
+		(0x3001 <= r && r <= 0xD7FF) || (0xF900 <= r && r <= 0xFDCF) ||
+		(0xFDF0 <= r && r <= 0xFFFD) || (0x10000 <= r && r <= 0xEFFFF)
+}
+
+func isNameChar(r rune) bool {
+	return isNameStartChar(r) ||
+		r == '-' || r == '.' || ('0' <= r && r <= '9') || r == 0xB7 ||
+		(0x0300 <= r && r <= 0x036F) || (0x203F <= r && r <= 0x2040)
+}
+
+// iunreserved = ALPHA / DIGIT / "-" / "." / "_" / "~" / ucschar
+func isIUnreserved(r rune) bool {
+	return ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') ||
+		r == '-' || r == '.' || r == '_' || r == '~' || isUCSChar(r)
+}
+
+func isUCSChar(r rune) bool {
+	switch {
+	case 0xA0 <= r && r <= 0xD7FF, 0xF900 <= r && r <= 0xFDCF, 0xFDF0 <= r && r <= 0xFFEF:
+		return true
+	case 0xE0000 <= r && r <= 0xE0FFF:
+		return false
+	case 0x10000 <= r && r <= 0xEFFFD:
+		// planes 1 - 14 excluding non-characters xFFFE and xFFFF
+		return r&0xFFFF < 0xFFFE
+	}
+	return false
+}
+
+func isIPrivate(r rune) bool {
+	return (0xE000 <= r && r <= 0xF8FF) ||
+		(0xF0000 <= r && r <= 0xFFFFD) || (0x100000 <= r && r <= 0x10FFFD)
+}
+
+// sub-delims = "!" / "$" / "&" / "'" / "(" / ")" / "*" / "+" / "," / ";" / "="
+func isSubDelim(r rune) bool {
+	return r < utf8.RuneSelf && strings.IndexByte("!$&'()*+,;=", byte(r)) != -1
+}
+
+// ipchar = iunreserved / pct-encoded / sub-delims / ":" / "@"
+func isIPChar(r rune) bool {
+	return isIUnreserved(r) || isSubDelim(r) || r == ':' || r == '@'
+}
+
+func isAuthority(r rune) bool { return isIPChar(r) || r == '[' || r == ']' }
+func isPath(r rune) bool      { return isIPChar(r) || r == '/' }
+func isQuery(r rune) bool     { return isIPChar(r) || isIPrivate(r) || r == '/' || r == '?' }
+func isFragment(r rune) bool  { return isIPChar(r) || r == '/' || r == '?' }
@@ -0,0 +1,144 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/fogfish/curie/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestParse(t *testing.T) {
+	for _, id := range []string{
+		"",
+		"a:",
+		"a:b",
+		"a:b/c/d",
+		":b",
+		"b",
+		"b/c/d",
+		"_:b0",
+		"foaf:Person",
+		"x-y.z:1",
+		"wiki:Ῥόδος",
+		"wiki:%E1%BF%AC",
+		"a:b?c=d&e=f#g",
+		"a:/b/c",
+		"a:b/c:d",
+		"https://example.com:8080/a/b?c#d",
+		"a:b?\U000F0000",
+	} {
+		t.Run(fmt.Sprintf("(%s)", id), func(t *testing.T) {
+			iri, err := curie.Parse(id)
+
+			it.Then(t).Should(
+				it.Nil(err),
+				it.Equal(iri, curie.IRI(id)),
+			)
+		})
+	}
+}
+
+func TestParseFail(t *testing.T) {
+	for id, expect := range map[string]struct {
+		err    error
+		offset int
+	}{
+		"1bad:x":   {curie.ErrInvalidPrefix, 0},
+		"a b:x":    {curie.ErrInvalidPrefix, 1},
+		":":        {nil, 0},
+		"a:b c":    {curie.ErrInvalidReference, 3},
+		" a:b":     {curie.ErrInvalidPrefix, 0},
+		"a:b ":     {curie.ErrInvalidReference, 3},
+		"a:b:c":    {curie.ErrInvalidReference, 3},
+		"a:%zz":    {curie.ErrInvalidReference, 2},
+		"a:b%2":    {curie.ErrInvalidReference, 3},
+		"a:b#c#d":  {curie.ErrInvalidReference, 5},
+		"a:b?c#d{": {curie.ErrInvalidReference, 7},
+		"a:\xff":   {curie.ErrInvalidReference, 2},
+		"a://h^/b": {curie.ErrInvalidReference, 5},
+	} {
+		t.Run(fmt.Sprintf("(%s)", id), func(t *testing.T) {
+			_, err := curie.Parse(id)
+			if expect.err == nil {
+				it.Then(t).Should(it.Nil(err))
+				return
+			}
+
+			var e *curie.SyntaxError
+			it.Then(t).Should(
+				it.True(errors.Is(err, expect.err)),
+				it.True(errors.As(err, &e)),
+				it.Equal(e.Offset, expect.offset),
+				it.Equal(e.Input, id),
+			)
+		})
+	}
+}
+
+func TestParseLimits(t *testing.T) {
+	t.Run("MaxLength", func(t *testing.T) {
+		_, err1 := curie.Parse("a:b/c", curie.WithMaxLength(5))
+		_, err2 := curie.Parse("a:b/c/d", curie.WithMaxLength(5))
+
+		it.Then(t).Should(
+			it.Nil(err1),
+			it.True(errors.Is(err2, curie.ErrTooLong)),
+		)
+	})
+
+	t.Run("MaxSegments", func(t *testing.T) {
+		_, err1 := curie.Parse("a:b/c", curie.WithMaxSegments(2))
+		_, err2 := curie.Parse("a:/b/c", curie.WithMaxSegments(2))
+		_, err3 := curie.Parse("a:b/c/d", curie.WithMaxSegments(2))
+
+		var e *curie.SyntaxError
+		it.Then(t).Should(
+			it.Nil(err1),
+			it.Nil(err2),
+			it.True(errors.Is(err3, curie.ErrTooManySegments)),
+			it.True(errors.As(err3, &e)),
+			it.Equal(e.Offset, 6),
+		)
+	})
+}
+
+func TestParseSafe(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		iri, err := curie.ParseSafe("[a:b/c]")
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(iri, "a:b/c"),
+		)
+	})
+
+	t.Run("Unbracketed", func(t *testing.T) {
+		_, err := curie.ParseSafe("a:b/c")
+
+		it.Then(t).Should(
+			it.True(errors.Is(err, curie.ErrInvalidSafeCURIE)),
+		)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := curie.ParseSafe("[a:b c]")
+
+		var e *curie.SyntaxError
+		it.Then(t).Should(
+			it.True(errors.Is(err, curie.ErrInvalidReference)),
+			it.True(errors.As(err, &e)),
+			it.Equal(e.Input, "[a:b c]"),
+			it.Equal(e.Offset, 4),
+		)
+	})
+}
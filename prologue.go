@@ -0,0 +1,356 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ErrInvalidPrologue is returned when prologue of RDF document cannot be parsed
+var ErrInvalidPrologue = errors.New("invalid prologue")
+
+// ErrNotEnumerable is returned when Prefixes do not implement Enumerable
+var ErrNotEnumerable = errors.New("prefixes are not enumerable")
+
+// Dialect of the prologue
+type Dialect int
+
+const (
+	// Turtle, TriG and N3 directives
+	//
+	//	@base <http://example.com/> .
+	//	@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+	Turtle Dialect = iota
+
+	// SPARQL and SPARQL-style Turtle directives
+	//
+	//	BASE <http://example.com/>
+	//	PREFIX foaf: <http://xmlns.com/foaf/0.1/>
+	SPARQL
+)
+
+// Prologue is the base IRI and prefixes declared by RDF document
+// (Turtle, TriG, N3) or SPARQL query. The empty prefix `:` is DefaultPrefix.
+type Prologue struct {
+	Base       string
+	Namespaces Namespaces
+}
+
+// ReadPrologue reads directives from the beginning of RDF document or SPARQL
+// query, both Turtle and SPARQL dialects are supported, comments are skipped.
+// The reading stops at the first statement that is not a directive.
+// Relative IRIs are resolved against the base declared by the prologue.
+func ReadPrologue(r io.Reader) (Prologue, error) {
+	p := &prologueReader{r: bufio.NewReader(r), line: 1}
+	prologue := Prologue{Namespaces: Namespaces{}}
+
+	for {
+		keyword, err := p.keyword()
+		if err != nil {
+			return prologue, err
+		}
+
+		var dialect Dialect
+		switch {
+		case keyword == "@prefix" || keyword == "@base":
+			dialect = Turtle
+		case strings.EqualFold(keyword, "PREFIX") || strings.EqualFold(keyword, "BASE"):
+			dialect = SPARQL
+		default:
+			return prologue, nil
+		}
+
+		prefix := ""
+		isPrefix := strings.EqualFold(keyword, "@prefix") || strings.EqualFold(keyword, "PREFIX")
+		if isPrefix {
+			if prefix, err = p.prefix(); err != nil {
+				return prologue, err
+			}
+		}
+
+		iri, err := p.iri()
+		if err != nil {
+			return prologue, err
+		}
+		if len(prologue.Base) != 0 {
+			iri = string(ResolveReference(IRI(prologue.Base), iri))
+		}
+
+		if dialect == Turtle {
+			if err := p.expect('.'); err != nil {
+				return prologue, err
+			}
+		}
+
+		if isPrefix {
+			prologue.Namespaces[prefix] = iri
+		} else {
+			prologue.Base = iri
+		}
+	}
+}
+
+// WritePrologue writes directives declaring prefixes, prefixes are sorted.
+// It fails with ErrNotEnumerable if prefixes are not Enumerable.
+func WritePrologue(w io.Writer, prefixes Prefixes, dialect Dialect) error {
+	ns, ok := namespacesOf(prefixes)
+	if !ok {
+		return ErrNotEnumerable
+	}
+
+	return Prologue{Namespaces: ns}.Write(w, dialect)
+}
+
+// Write directives of the prologue, prefixes are sorted.
+// The blank node prefix is not written.
+func (p Prologue) Write(w io.Writer, dialect Dialect) error {
+	prefixes := make([]string, 0, len(p.Namespaces))
+	for prefix := range p.Namespaces {
+		if prefix == BlankNode {
+			continue
+		}
+		if prefix != DefaultPrefix && !isPNPrefix(prefix) {
+			return fmt.Errorf("%w: %q is not valid in %s", ErrInvalidPrefix, prefix, dialect)
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+
+	b := bufio.NewWriter(w)
+	if len(p.Base) != 0 {
+		if dialect == Turtle {
+			fmt.Fprintf(b, "@base <%s> .\n", escapeIRIRef(p.Base))
+		} else {
+			fmt.Fprintf(b, "BASE <%s>\n", escapeIRIRef(p.Base))
+		}
+	}
+
+	for _, prefix := range prefixes {
+		if dialect == Turtle {
+			fmt.Fprintf(b, "@prefix %s: <%s> .\n", prefix, escapeIRIRef(p.Namespaces[prefix]))
+		} else {
+			fmt.Fprintf(b, "PREFIX %s: <%s>\n", prefix, escapeIRIRef(p.Namespaces[prefix]))
+		}
+	}
+
+	return b.Flush()
+}
+
+func (d Dialect) String() string {
+	switch d {
+	case Turtle:
+		return "Turtle"
+	case SPARQL:
+		return "SPARQL"
+	default:
+		return fmt.Sprintf("Dialect(%d)", int(d))
+	}
+}
+
+// PN_PREFIX of Turtle and SPARQL is NCName, which neither starts with
+// underscore nor ends with period.
+func isPNPrefix(s string) bool {
+	return isNCName(s) && s[0] != '_' && s[len(s)-1] != '.'
+}
+
+// IRIREF excludes controls, space and <>"{}|^`\ characters, they are escaped
+func escapeIRIRef(iri string) string {
+	var b strings.Builder
+	for _, r := range iri {
+		if r <= 0x20 || strings.ContainsRune("<>\"{}|^`\\", r) {
+			fmt.Fprintf(&b, "\\u%04X", r)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+//------------------------------------------------------------------------------
+
+type prologueReader struct {
+	r    *bufio.Reader
+	line int
+}
+
+func (p *prologueReader) fail(reason string) error {
+	return fmt.Errorf("%w: line %d: %s", ErrInvalidPrologue, p.line, reason)
+}
+
+func (p *prologueReader) read() (rune, error) {
+	r, _, err := p.r.ReadRune()
+	if r == '\n' {
+		p.line++
+	}
+	return r, err
+}
+
+func (p *prologueReader) unread(r rune) {
+	p.r.UnreadRune()
+	if r == '\n' {
+		p.line--
+	}
+}
+
+// skip whitespaces and comments
+func (p *prologueReader) skip() error {
+	for {
+		r, err := p.read()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case r == '#':
+			for r != '\n' {
+				if r, err = p.read(); err != nil {
+					return err
+				}
+			}
+		case !unicode.IsSpace(r):
+			p.unread(r)
+			return nil
+		}
+	}
+}
+
+// read directive keyword, empty string at the end of prologue
+func (p *prologueReader) keyword() (string, error) {
+	if err := p.skip(); err != nil {
+		if err == io.EOF {
+			return "", nil
+		}
+		return "", err
+	}
+
+	var b strings.Builder
+	for {
+		r, err := p.read()
+		if err == io.EOF {
+			return b.String(), nil
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if !(r == '@' && b.Len() == 0) && !unicode.IsLetter(r) {
+			p.unread(r)
+			if r == ':' {
+				// prefixed name (e.g. base:x) is not a directive
+				return "", nil
+			}
+			return b.String(), nil
+		}
+		b.WriteRune(r)
+	}
+}
+
+// read PNAME_NS, the prefix followed by colon
+func (p *prologueReader) prefix() (string, error) {
+	if err := p.skip(); err != nil {
+		return "", p.fail("prefix is expected")
+	}
+
+	var b strings.Builder
+	for {
+		r, err := p.read()
+		if err != nil {
+			return "", p.fail("prefix is expected")
+		}
+
+		if r == ':' {
+			break
+		}
+		if unicode.IsSpace(r) || r == '<' {
+			return "", p.fail("prefix is expected")
+		}
+		b.WriteRune(r)
+	}
+
+	prefix := b.String()
+	if len(prefix) != 0 && !isNCName(prefix) {
+		return "", p.fail(fmt.Sprintf("invalid prefix %q", prefix))
+	}
+
+	return prefix, nil
+}
+
+// read IRIREF, decoding escaped characters
+func (p *prologueReader) iri() (string, error) {
+	if err := p.expect('<'); err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	for {
+		r, err := p.read()
+		if err != nil {
+			return "", p.fail("unterminated IRI")
+		}
+
+		switch {
+		case r == '>':
+			return b.String(), nil
+		case r == '\\':
+			if r, err = p.uchar(); err != nil {
+				return "", err
+			}
+		case r <= 0x20 || strings.ContainsRune("<\"{}|^`", r):
+			return "", p.fail(fmt.Sprintf("invalid character %q in IRI", r))
+		}
+		b.WriteRune(r)
+	}
+}
+
+// read UCHAR, \uXXXX or \UXXXXXXXX
+func (p *prologueReader) uchar() (rune, error) {
+	r, err := p.read()
+	if err != nil || (r != 'u' && r != 'U') {
+		return 0, p.fail("invalid escape in IRI")
+	}
+
+	n := 4
+	if r == 'U' {
+		n = 8
+	}
+
+	hex := make([]rune, n)
+	for i := range hex {
+		if hex[i], err = p.read(); err != nil {
+			return 0, p.fail("invalid escape in IRI")
+		}
+	}
+
+	code, err := strconv.ParseUint(string(hex), 16, 32)
+	if err != nil {
+		return 0, p.fail("invalid escape in IRI")
+	}
+
+	return rune(code), nil
+}
+
+func (p *prologueReader) expect(x rune) error {
+	if err := p.skip(); err != nil {
+		return p.fail(fmt.Sprintf("%q is expected", x))
+	}
+
+	r, err := p.read()
+	if err != nil || r != x {
+		return p.fail(fmt.Sprintf("%q is expected", x))
+	}
+
+	return nil
+}
@@ -0,0 +1,178 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/fogfish/curie/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestReadPrologue(t *testing.T) {
+	t.Run("Turtle", func(t *testing.T) {
+		p, err := curie.ReadPrologue(strings.NewReader(`
+			# ontology
+			@base <http://example.com/> .
+			@prefix foaf: <http://xmlns.com/foaf/0.1/> . # people
+			@prefix : <vocab#> .
+			@prefix rel:<relation/>.
+			PREFIX dc: <http://purl.org/dc/terms/>
+
+			<#me> a foaf:Person ;
+				foaf:name "@prefix no: <http://no/> ." .
+			@prefix late: <http://late/> .
+		`))
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(p.Base, "http://example.com/"),
+			it.Equiv(p.Namespaces, curie.Namespaces{
+				"foaf":              "http://xmlns.com/foaf/0.1/",
+				curie.DefaultPrefix: "http://example.com/vocab#",
+				"rel":               "http://example.com/relation/",
+				"dc":                "http://purl.org/dc/terms/",
+			}),
+		)
+	})
+
+	t.Run("SPARQL", func(t *testing.T) {
+		p, err := curie.ReadPrologue(strings.NewReader(`
+			base <http://example.com/a/>
+			PREFIX foaf: <http://xmlns.com/foaf/0.1/>
+			Prefix x: <../x/>
+			SELECT ?name WHERE { ?x foaf:name ?name . FILTER(?x < 5) }
+		`))
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(p.Base, "http://example.com/a/"),
+			it.Equiv(p.Namespaces, curie.Namespaces{
+				"foaf": "http://xmlns.com/foaf/0.1/",
+				"x":    "http://example.com/x/",
+			}),
+		)
+	})
+
+	t.Run("PrefixedName", func(t *testing.T) {
+		p, err := curie.ReadPrologue(strings.NewReader(`
+			@prefix base: <http://example.com/> .
+			base:a base:b base:c .
+		`))
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equiv(p.Namespaces, curie.Namespaces{"base": "http://example.com/"}),
+		)
+	})
+
+	for _, doc := range []string{
+		"@prefix foaf: <http://xmlns.com/foaf/0.1/>",
+		"@prefix foaf <http://xmlns.com/foaf/0.1/> .",
+		"@prefix 1foaf: <http://xmlns.com/foaf/0.1/> .",
+		"PREFIX foaf: <http://xmlns.com/foaf/0.1/",
+		"PREFIX foaf: <http://xmlns.com/foaf 0.1/>",
+		"PREFIX foaf: <http://xmlns.com/foaf/\\x>",
+	} {
+		t.Run(fmt.Sprintf("(%s)", doc), func(t *testing.T) {
+			_, err := curie.ReadPrologue(strings.NewReader(doc))
+
+			it.Then(t).Should(
+				it.True(errors.Is(err, curie.ErrInvalidPrologue)),
+			)
+		})
+	}
+}
+
+func TestWritePrologue(t *testing.T) {
+	ns := curie.Namespaces{
+		curie.DefaultPrefix: "http://example.com/vocab#",
+		curie.BlankNode:     "_:",
+		"foaf":              "http://xmlns.com/foaf/0.1/",
+		"dc":                "http://purl.org/dc/terms/",
+		"x":                 "http://example.com/x y/",
+	}
+
+	t.Run("Turtle", func(t *testing.T) {
+		var b bytes.Buffer
+		err := curie.WritePrologue(&b, curie.NewTrie(ns), curie.Turtle)
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(b.String(), `@prefix : <http://example.com/vocab#> .
+@prefix dc: <http://purl.org/dc/terms/> .
+@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+@prefix x: <http://example.com/x\u0020y/> .
+`),
+		)
+	})
+
+	t.Run("SPARQL", func(t *testing.T) {
+		var b bytes.Buffer
+		err := curie.Prologue{Base: "http://example.com/", Namespaces: ns}.Write(&b, curie.SPARQL)
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(b.String(), `BASE <http://example.com/>
+PREFIX : <http://example.com/vocab#>
+PREFIX dc: <http://purl.org/dc/terms/>
+PREFIX foaf: <http://xmlns.com/foaf/0.1/>
+PREFIX x: <http://example.com/x\u0020y/>
+`),
+		)
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		var b bytes.Buffer
+		err1 := curie.WritePrologue(&b, ns, curie.Turtle)
+		p, err2 := curie.ReadPrologue(&b)
+
+		delete(ns, curie.BlankNode)
+		it.Then(t).Should(
+			it.Nil(err1),
+			it.Nil(err2),
+			it.Equiv(p.Namespaces, ns),
+		)
+	})
+
+	t.Run("InvalidPrefix", func(t *testing.T) {
+		var b bytes.Buffer
+		err := curie.WritePrologue(&b, curie.Namespaces{"ex.": "http://example.com/"}, curie.Turtle)
+
+		it.Then(t).Should(
+			it.True(errors.Is(err, curie.ErrInvalidPrefix)),
+		)
+	})
+
+	t.Run("NotEnumerable", func(t *testing.T) {
+		var b bytes.Buffer
+		err := curie.WritePrologue(&b, struct{ curie.Prefixes }{ns}, curie.Turtle)
+
+		it.Then(t).Should(
+			it.True(errors.Is(err, curie.ErrNotEnumerable)),
+		)
+	})
+
+	t.Run("Registry", func(t *testing.T) {
+		r := curie.NewRegistry()
+		r.Register("foaf", "http://xmlns.com/foaf/0.1/")
+
+		var b bytes.Buffer
+		err := curie.WritePrologue(&b, r, curie.SPARQL)
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(b.String(), "PREFIX foaf: <http://xmlns.com/foaf/0.1/>\n"),
+		)
+	})
+}
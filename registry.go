@@ -0,0 +1,162 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// Errors returned by the registry, use errors.Is to distinguish them
+var (
+	ErrPrefixConflict    = errors.New("prefix conflict")
+	ErrExpansionConflict = errors.New("expansion conflict")
+)
+
+// Snapshot is immutable, versioned state of prefixes
+type Snapshot struct {
+	*Trie
+	Version uint64
+}
+
+var _ Prefixes = (*Snapshot)(nil)
+
+// Op is the type of change
+type Op int
+
+const (
+	Registered Op = iota + 1
+	Unregistered
+)
+
+// Change of the registry, delivered to subscribers
+type Change struct {
+	Op      Op
+	Prefix  string
+	IRI     string
+	Version uint64
+}
+
+// Registry is concurrent, mutable collection of prefixes.
+// Reads are lock-free, they use the atomic snapshot of prefixes.
+// Writes are serialized, each one produces new snapshot.
+//
+// Use Snapshot for consistent view of prefixes across multiple operations.
+type Registry struct {
+	mu        sync.Mutex
+	snapshot  atomic.Pointer[Snapshot]
+	observers map[int]func(Change)
+	seq       int
+}
+
+var _ Prefixes = (*Registry)(nil)
+
+// NewRegistry creates empty registry
+func NewRegistry() *Registry {
+	r := &Registry{observers: make(map[int]func(Change))}
+	r.snapshot.Store(&Snapshot{Trie: NewTrie(nil)})
+	return r
+}
+
+// Snapshot returns current state of the registry
+func (r *Registry) Snapshot() *Snapshot { return r.snapshot.Load() }
+
+// Create new URI using current snapshot of prefixes
+func (r *Registry) Create(uri string) IRI { return r.snapshot.Load().Create(uri) }
+
+// Lookup prefix in current snapshot of prefixes
+func (r *Registry) Lookup(prefix string) (string, bool) {
+	return r.snapshot.Load().Lookup(prefix)
+}
+
+// Range iterates over current snapshot of prefixes
+func (r *Registry) Range(f func(prefix, iri string) bool) {
+	r.snapshot.Load().Range(f)
+}
+
+// template of current snapshot
+func (r *Registry) template(prefix, iri string) (*uriTemplate, bool) {
+	return r.snapshot.Load().template(prefix, iri)
+}
+
+// Register prefix. It fails if the prefix is bound to another IRI
+// (ErrPrefixConflict) or the IRI is bound to another prefix (ErrExpansionConflict),
+// only one of them would be used by compaction. Nested expansions are allowed,
+// the compaction uses the longest one (see Lint). Registering the same binding
+// twice is no-op.
+//
+// The snapshot is updated copy-on-write, the cost of registration does not
+// depend on the number of prefixes.
+func (r *Registry) Register(prefix, iri string) error {
+	if prefix != DefaultPrefix && !isNCName(prefix) {
+		return fmt.Errorf("%w: %q is not NCName", ErrInvalidPrefix, prefix)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	snapshot := r.snapshot.Load()
+	if val, has := snapshot.Lookup(prefix); has {
+		if val == iri {
+			return nil
+		}
+		return fmt.Errorf("%w: %s is bound to %s", ErrPrefixConflict, prefix, val)
+	}
+
+	if key, has := snapshot.prefixOf(iri); has {
+		return fmt.Errorf("%w: %s is bound to %s", ErrExpansionConflict, iri, key)
+	}
+
+	r.commit(snapshot.with(prefix, iri), Change{Op: Registered, Prefix: prefix, IRI: iri})
+	return nil
+}
+
+// Unregister prefix, returns false if prefix is not registered
+func (r *Registry) Unregister(prefix string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	snapshot := r.snapshot.Load()
+	iri, has := snapshot.Lookup(prefix)
+	if !has {
+		return false
+	}
+
+	r.commit(snapshot.without(prefix), Change{Op: Unregistered, Prefix: prefix, IRI: iri})
+	return true
+}
+
+func (r *Registry) commit(t *Trie, change Change) {
+	change.Version = r.snapshot.Load().Version + 1
+	r.snapshot.Store(&Snapshot{Trie: t, Version: change.Version})
+
+	for _, f := range r.observers {
+		f(change)
+	}
+}
+
+// Subscribe to changes of the registry. The function is called synchronously
+// after the change is committed, changes are delivered in order.
+// The function must not modify the registry.
+func (r *Registry) Subscribe(f func(Change)) (cancel func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.seq++
+	id := r.seq
+	r.observers[id] = f
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.observers, id)
+	}
+}
@@ -0,0 +1,151 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie_test
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/fogfish/curie/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestRegistry(t *testing.T) {
+	r := curie.NewRegistry()
+
+	err1 := r.Register("ex", "https://example.com/")
+	err2 := r.Register("exa", "https://example.com/a/")
+	err3 := r.Register("ex", "https://example.com/")
+
+	it.Then(t).Should(
+		it.Nil(err1),
+		it.Nil(err2),
+		it.Nil(err3),
+		it.Equal(r.Snapshot().Version, 2),
+		it.Equal(curie.FromURI(r, "https://example.com/a/b"), "exa:b"),
+		it.Equal(curie.URI(r, "ex:b"), "https://example.com/b"),
+	)
+
+	it.Then(t).Should(
+		it.True(r.Unregister("exa")),
+		it.True(!r.Unregister("exa")),
+		it.Equal(r.Snapshot().Version, 3),
+		it.Equal(curie.FromURI(r, "https://example.com/a/b"), "ex:a/b"),
+		it.Equal(curie.URI(r, "exa:b"), "exa:b"),
+	)
+}
+
+func TestRegistryConflict(t *testing.T) {
+	r := curie.NewRegistry()
+	r.Register("ex", "https://example.com/")
+
+	it.Then(t).Should(
+		it.True(errors.Is(r.Register("ex", "https://example.org/"), curie.ErrPrefixConflict)),
+		it.True(errors.Is(r.Register("xe", "https://example.com/"), curie.ErrExpansionConflict)),
+		it.True(errors.Is(r.Register("1x", "https://example.net/"), curie.ErrInvalidPrefix)),
+		it.Equal(r.Snapshot().Version, 1),
+	)
+}
+
+func TestRegistrySnapshot(t *testing.T) {
+	r := curie.NewRegistry()
+	r.Register("ex", "https://example.com/")
+
+	snapshot := r.Snapshot()
+	r.Unregister("ex")
+
+	it.Then(t).Should(
+		it.Equal(curie.URI(snapshot, "ex:a"), "https://example.com/a"),
+		it.Equal(curie.URI(r, "ex:a"), "ex:a"),
+	)
+}
+
+func TestRegistrySubscribe(t *testing.T) {
+	r := curie.NewRegistry()
+
+	var seq []curie.Change
+	cancel := r.Subscribe(func(c curie.Change) { seq = append(seq, c) })
+
+	r.Register("ex", "https://example.com/")
+	r.Register("ex", "https://example.org/")
+	r.Unregister("ex")
+	cancel()
+	r.Register("ex", "https://example.com/")
+
+	it.Then(t).Should(
+		it.Seq(seq).Equal(
+			curie.Change{Op: curie.Registered, Prefix: "ex", IRI: "https://example.com/", Version: 1},
+			curie.Change{Op: curie.Unregistered, Prefix: "ex", IRI: "https://example.com/", Version: 2},
+		),
+	)
+}
+
+func TestRegistryConcurrent(t *testing.T) {
+	r := curie.NewRegistry()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			r.Register(fmt.Sprintf("p%d", i), fmt.Sprintf("https://example.com/%d/", i))
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			curie.URI(r, curie.IRI(fmt.Sprintf("p%d:a", i)))
+		}(i)
+	}
+	wg.Wait()
+
+	it.Then(t).Should(
+		it.Equal(r.Snapshot().Version, 16),
+		it.Equal(curie.FromURI(r, "https://example.com/7/a"), "p7:a"),
+	)
+}
+
+func TestRegistryBulk(t *testing.T) {
+	r := curie.NewRegistry()
+
+	for i := 0; i < 5000; i++ {
+		err := r.Register(fmt.Sprintf("p%d", i), fmt.Sprintf("https://example.com/%d/", i))
+		it.Then(t).Should(it.Nil(err))
+	}
+
+	snapshot := r.Snapshot()
+	for i := 0; i < 5000; i += 2 {
+		r.Unregister(fmt.Sprintf("p%d", i))
+	}
+
+	n := 0
+	r.Range(func(string, string) bool { n++; return true })
+
+	it.Then(t).Should(
+		it.Equal(r.Snapshot().Version, 7500),
+		it.Equal(n, 2500),
+		it.Equal(curie.FromURI(r, "https://example.com/4999/a"), "p4999:a"),
+		it.Equal(curie.FromURI(r, "https://example.com/4998/a"), "https://example.com/4998/a"),
+		it.Equal(curie.FromURI(snapshot, "https://example.com/4998/a"), "p4998:a"),
+		it.True(errors.Is(r.Register("x", "https://example.com/4999/"), curie.ErrExpansionConflict)),
+	)
+}
+
+func TestRegistryTemplate(t *testing.T) {
+	r := curie.NewRegistry()
+
+	it.Then(t).Should(
+		it.Nil(r.Register("gh", "https://github.com/{owner}/{repo}")),
+		it.Equal(curie.URI(r, "gh:fogfish/curie"), "https://github.com/fogfish/curie"),
+		it.Equal(curie.FromURI(r, "https://github.com/fogfish/curie"), "gh:fogfish/curie"),
+		it.True(errors.Is(r.Register("g", "https://github.com/{owner}/{repo}"), curie.ErrExpansionConflict)),
+		it.True(r.Unregister("gh")),
+		it.Equal(curie.FromURI(r, "https://github.com/fogfish/curie"), "https://github.com/fogfish/curie"),
+	)
+}
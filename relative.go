@@ -0,0 +1,207 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie
+
+import (
+	"strings"
+
+	"github.com/fogfish/curie/v2/internal/reference"
+)
+
+// ResolveReference resolves relative reference against the base CURIE, as
+// defined by RFC 3986 section 5.2. The algorithm is applied to the
+// reference of base CURIE, the prefix is not changed.
+//
+//	a:b/c/d;p?q × ../g ⟼ a:b/g
+//	a:b/c/d;p?q × #s   ⟼ a:b/c/d;p?q#s
+//
+// The reference with scheme is absolute IRI, it is returned as is.
+func ResolveReference(base IRI, ref string) IRI {
+	if n := strings.IndexAny(ref, ":/?#"); n > 0 && ref[n] == ':' && isScheme(ref[:n]) {
+		return IRI(ref)
+	}
+
+	schema, b := Split(base)
+	r := splitRef(ref)
+	t := splitRef(b)
+
+	switch {
+	case r.hasAuthority:
+		t.authority, t.hasAuthority = r.authority, r.hasAuthority
+		t.path = removeDotSegments(r.path)
+		t.query, t.hasQuery = r.query, r.hasQuery
+	case len(r.path) == 0:
+		if r.hasQuery {
+			t.query, t.hasQuery = r.query, r.hasQuery
+		}
+	case r.path[0] == '/':
+		t.path = removeDotSegments(r.path)
+		t.query, t.hasQuery = r.query, r.hasQuery
+	default:
+		t.path = removeDotSegments(t.merge(r.path))
+		t.query, t.hasQuery = r.query, r.hasQuery
+	}
+	t.fragment, t.hasFragment = r.fragment, r.hasFragment
+
+	return New(schema, t.String())
+}
+
+// Relativize computes relative reference of the target CURIE against the base,
+// it is an inverse of ResolveReference
+//
+//	ResolveReference(base, Relativize(base, target)) == target
+//
+// CURIEs with distinct prefixes cannot be relativized.
+func Relativize(base, target IRI) (string, bool) {
+	bs, b := Split(base)
+	ts, t := Split(target)
+	if bs != ts {
+		return "", false
+	}
+
+	br := splitRef(b)
+	tr := splitRef(t)
+
+	if br.hasAuthority != tr.hasAuthority || br.authority != tr.authority {
+		if !tr.hasAuthority {
+			return "", false
+		}
+		return tr.String(), true
+	}
+
+	rel := ref{
+		query: tr.query, hasQuery: tr.hasQuery,
+		fragment: tr.fragment, hasFragment: tr.hasFragment,
+	}
+
+	switch {
+	case br.path == tr.path && (tr.hasQuery || !br.hasQuery):
+		if tr.hasQuery && br.hasQuery && tr.query == br.query {
+			rel.query, rel.hasQuery = "", false
+		}
+	case br.path == tr.path:
+		if len(tr.path) == 0 {
+			return "", false
+		}
+		rel.path = relativeSegment(tr.path[strings.LastIndexByte(tr.path, '/')+1:])
+	case strings.HasPrefix(tr.path, "/") && !strings.HasPrefix(br.path, "/"):
+		if strings.HasPrefix(tr.path, "//") {
+			return "", false
+		}
+		rel.path = tr.path
+	case !strings.HasPrefix(tr.path, "/") && strings.HasPrefix(br.path, "/"):
+		return "", false
+	default:
+		rel.path = relativePath(br.path, tr.path)
+	}
+
+	return rel.String(), true
+}
+
+// relative path from base to target, both paths are either rooted or not
+func relativePath(base, target string) string {
+	dir := base[:strings.LastIndexByte(base, '/')+1]
+
+	last := 0
+	for i := 0; i < len(dir) && i < len(target) && dir[i] == target[i]; i++ {
+		if dir[i] == '/' {
+			last = i + 1
+		}
+	}
+
+	ups := strings.Count(dir[last:], "/")
+	if ups == 0 {
+		return relativeSegment(target[last:])
+	}
+
+	return strings.Repeat("../", ups) + target[last:]
+}
+
+// guards relative path from being interpreted as empty, absolute or scheme
+func relativeSegment(path string) string {
+	seg := path
+	if n := strings.IndexByte(path, '/'); n != -1 {
+		seg = path[:n]
+	}
+
+	if len(path) == 0 || path[0] == '/' || strings.IndexByte(seg, ':') != -1 {
+		return "./" + path
+	}
+
+	return path
+}
+
+// remove dot-segments, relative path is processed as it is rooted
+func removeDotSegments(path string) string {
+	if len(path) == 0 || path[0] == '/' {
+		return reference.RemoveDotSegments(path)
+	}
+
+	return reference.RemoveDotSegments("/" + path)[1:]
+}
+
+// components of relative reference
+//
+//	relative-ref = relative-part [ "?" query ] [ "#" fragment ]
+type ref struct {
+	authority    string
+	hasAuthority bool
+	path         string
+	query        string
+	hasQuery     bool
+	fragment     string
+	hasFragment  bool
+}
+
+func splitRef(s string) ref {
+	var r ref
+
+	if n := strings.IndexByte(s, '#'); n != -1 {
+		s, r.fragment, r.hasFragment = s[:n], s[n+1:], true
+	}
+
+	if n := strings.IndexByte(s, '?'); n != -1 {
+		s, r.query, r.hasQuery = s[:n], s[n+1:], true
+	}
+
+	if strings.HasPrefix(s, "//") {
+		n := 2 + strings.IndexByte(s[2:]+"/", '/')
+		s, r.authority, r.hasAuthority = s[n:], s[2:n], true
+	}
+
+	r.path = s
+	return r
+}
+
+// merge relative path with the base one, RFC 3986 section 5.2.3
+func (r ref) merge(path string) string {
+	if r.hasAuthority && len(r.path) == 0 {
+		return "/" + path
+	}
+
+	return r.path[:strings.LastIndexByte(r.path, '/')+1] + path
+}
+
+func (r ref) String() string {
+	var b strings.Builder
+	if r.hasAuthority {
+		b.WriteString("//")
+		b.WriteString(r.authority)
+	}
+	b.WriteString(r.path)
+	if r.hasQuery {
+		b.WriteString("?")
+		b.WriteString(r.query)
+	}
+	if r.hasFragment {
+		b.WriteString("#")
+		b.WriteString(r.fragment)
+	}
+	return b.String()
+}
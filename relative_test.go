@@ -0,0 +1,153 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/fogfish/curie/v2"
+	"github.com/fogfish/it/v2"
+)
+
+// See RFC 3986 section 5.4
+func TestResolveReference(t *testing.T) {
+	base := curie.IRI("http://a/b/c/d;p?q")
+
+	for ref, expected := range map[string]curie.IRI{
+		"g:h":           "g:h",
+		"g":             "http://a/b/c/g",
+		"./g":           "http://a/b/c/g",
+		"g/":            "http://a/b/c/g/",
+		"/g":            "http://a/g",
+		"//g":           "http://g",
+		"?y":            "http://a/b/c/d;p?y",
+		"g?y":           "http://a/b/c/g?y",
+		"#s":            "http://a/b/c/d;p?q#s",
+		"g#s":           "http://a/b/c/g#s",
+		"g?y#s":         "http://a/b/c/g?y#s",
+		";x":            "http://a/b/c/;x",
+		"g;x":           "http://a/b/c/g;x",
+		"g;x?y#s":       "http://a/b/c/g;x?y#s",
+		"":              "http://a/b/c/d;p?q",
+		".":             "http://a/b/c/",
+		"./":            "http://a/b/c/",
+		"..":            "http://a/b/",
+		"../":           "http://a/b/",
+		"../g":          "http://a/b/g",
+		"../..":         "http://a/",
+		"../../":        "http://a/",
+		"../../g":       "http://a/g",
+		"../../../g":    "http://a/g",
+		"../../../../g": "http://a/g",
+		"/./g":          "http://a/g",
+		"/../g":         "http://a/g",
+		"g.":            "http://a/b/c/g.",
+		".g":            "http://a/b/c/.g",
+		"g..":           "http://a/b/c/g..",
+		"..g":           "http://a/b/c/..g",
+		"./../g":        "http://a/b/g",
+		"./g/.":         "http://a/b/c/g/",
+		"g/./h":         "http://a/b/c/g/h",
+		"g/../h":        "http://a/b/c/h",
+		"g;x=1/./y":     "http://a/b/c/g;x=1/y",
+		"g;x=1/../y":    "http://a/b/c/y",
+		"g?y/./x":       "http://a/b/c/g?y/./x",
+		"g?y/../x":      "http://a/b/c/g?y/../x",
+		"g#s/./x":       "http://a/b/c/g#s/./x",
+		"g#s/../x":      "http://a/b/c/g#s/../x",
+	} {
+		t.Run(fmt.Sprintf("(%s)", ref), func(t *testing.T) {
+			it.Then(t).Should(
+				it.Equal(curie.ResolveReference(base, ref), expected),
+			)
+		})
+	}
+}
+
+func TestResolveReferenceCURIE(t *testing.T) {
+	for ref, expected := range map[string]curie.IRI{
+		"c":        "a:b/c",
+		"../c":     "a:c",
+		"../../c":  "a:c",
+		"/c":       "a:/c",
+		"?q":       "a:b/x?q",
+		"#f":       "a:b/x#f",
+		"./c:d":    "a:b/c:d",
+		"c/./d/..": "a:b/c/",
+		"//g":      "a://g",
+		"//g/h":    "a://g/h",
+		"//g/./h":  "a://g/h",
+		"//g/h?q":  "a://g/h?q",
+	} {
+		t.Run(fmt.Sprintf("(%s)", ref), func(t *testing.T) {
+			it.Then(t).Should(
+				it.Equal(curie.ResolveReference("a:b/x", ref), expected),
+			)
+		})
+	}
+
+	it.Then(t).Should(
+		it.Equal(curie.ResolveReference("a:", "b"), "a:b"),
+		it.Equal(curie.ResolveReference("b/c", "d"), "b/d"),
+		it.Equal(curie.ResolveReference("b/x", "//g/h?q"), "//g/h?q"),
+		it.Equal(curie.ResolveReference("a://g/h", "x"), "a://g/x"),
+	)
+}
+
+func TestRelativize(t *testing.T) {
+	for _, spec := range [][]curie.IRI{
+		{"a:b/c", "a:b/d", "d"},
+		{"a:b/c", "a:b/c", ""},
+		{"a:b/c", "a:b/c#f", "#f"},
+		{"a:b/c?q", "a:b/c?q", ""},
+		{"a:b/c?q", "a:b/c?r", "?r"},
+		{"a:b/c?q", "a:b/c", "c"},
+		{"a:b/c", "a:b/", "./"},
+		{"a:b/c", "a:x/y", "../x/y"},
+		{"a:b/c/d", "a:x", "../../x"},
+		{"a:b/c", "a:b/x:y", "./x:y"},
+		{"a:/b/c", "a:/b/d/e", "d/e"},
+		{"a:b", "a:/c", "/c"},
+		{"a:c", "a:d?q#f", "d?q#f"},
+		{"http://a/b/c", "http://x/y", "//x/y"},
+		{"http://a/b/c", "http://a/x", "../x"},
+		{"a:b/c", "a://x/y", "//x/y"},
+		{"a:b/c", "a://x", "//x"},
+		{"a://x/b", "a://y/b?q", "//y/b?q"},
+		{"b/c", "//x/y?q", "//x/y?q"},
+	} {
+		base, target, expected := spec[0], spec[1], string(spec[2])
+		t.Run(fmt.Sprintf("(%s, %s)", base, target), func(t *testing.T) {
+			rel, ok := curie.Relativize(base, target)
+
+			it.Then(t).Should(
+				it.True(ok),
+				it.Equal(rel, expected),
+				it.Equal(curie.ResolveReference(base, rel), target),
+			)
+		})
+	}
+}
+
+func TestRelativizeFail(t *testing.T) {
+	for _, spec := range [][]curie.IRI{
+		{"a:b", "x:b"},
+		{"a:/b", "a:c"},
+		{"http://a/b", "http:c"},
+	} {
+		t.Run(fmt.Sprintf("(%s, %s)", spec[0], spec[1]), func(t *testing.T) {
+			_, ok := curie.Relativize(spec[0], spec[1])
+
+			it.Then(t).ShouldNot(
+				it.True(ok),
+			)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownPrefix is returned when prefix is not defined by Prefixes,
+// the error is reported as *UnknownPrefixError
+var ErrUnknownPrefix = errors.New("unknown prefix")
+
+// Kind of the value resolved by SafeCURIEorCURIEorIRI datatype
+type Kind int
+
+const (
+	KindSafeCURIE Kind = iota + 1
+	KindCURIE
+	KindIRI
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindSafeCURIE:
+		return "SafeCURIE"
+	case KindCURIE:
+		return "CURIE"
+	case KindIRI:
+		return "IRI"
+	default:
+		return fmt.Sprintf("Kind(%d)", int(k))
+	}
+}
+
+// Resolve the value of SafeCURIEorCURIEorIRI datatype, as defined by
+// https://www.w3.org/TR/2010/NOTE-curie-20101216/
+//
+// The bracketed value is always safe CURIE, its prefix must be declared.
+// The value is CURIE if its prefix is declared by Prefixes, otherwise it is
+// absolute IRI. The blank node prefix `_` is always CURIE. The reference of
+// CURIE abbreviates absolute IRI, it might contain colons (e.g. wiki:Talk:CURIE).
+// The empty value is rejected.
+//
+//	[wiki:CURIE]      ⟼ wiki:CURIE, KindSafeCURIE
+//	wiki:CURIE        ⟼ wiki:CURIE, KindCURIE
+//	http://x/y        ⟼ http://x/y, KindIRI
+func Resolve(prefixes Prefixes, value string) (IRI, Kind, error) {
+	value = strings.TrimSpace(value)
+
+	if len(value) == 0 {
+		return Empty, 0, &SyntaxError{Input: value, Reason: "empty value", Err: ErrInvalidReference}
+	}
+
+	if value[0] == '[' {
+		iri, err := ParseSafe(value, declared())
+		if err != nil {
+			return Empty, KindSafeCURIE, err
+		}
+
+		if !isDeclared(prefixes, string(iri)) {
+			return Empty, KindSafeCURIE, unknownPrefix(prefixes, iri.Schema())
+		}
+
+		return iri, KindSafeCURIE, nil
+	}
+
+	if isDeclared(prefixes, value) {
+		iri, err := Parse(value, declared())
+		return iri, KindCURIE, err
+	}
+
+	if err := parseAbsolute(value); err != nil {
+		if errors.Is(err, ErrInvalidScheme) {
+			return Empty, KindIRI, unknownPrefix(prefixes, Schema(IRI(value)))
+		}
+		return Empty, KindIRI, err
+	}
+
+	return IRI(value), KindIRI, nil
+}
+
+// ResolveList resolves white space separated list of SafeCURIEorCURIEorIRI
+// values, as used by RDFa attributes. Invalid values are skipped,
+// the error joins all failures.
+func ResolveList(prefixes Prefixes, value string) ([]IRI, error) {
+	var errs []error
+
+	seq := strings.Fields(value)
+	iris := make([]IRI, 0, len(seq))
+	for _, x := range seq {
+		iri, _, err := Resolve(prefixes, x)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		iris = append(iris, iri)
+	}
+
+	return iris, errors.Join(errs...)
+}
+
+// checks if CURIE prefix is declared, CURIEs without prefix and blank nodes
+// are always declared.
+func isDeclared(prefixes Prefixes, value string) bool {
+	n := strings.IndexByte(value, ':')
+	if n == -1 {
+		return true
+	}
+
+	prefix := value[:n]
+	if prefix == "_" {
+		return true
+	}
+
+	_, exists := prefixes.Lookup(prefix)
+	return exists
+}
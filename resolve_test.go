@@ -0,0 +1,114 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/fogfish/curie/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestResolve(t *testing.T) {
+	prefixes := curie.Namespaces{
+		"wiki": "http://en.wikipedia.org/wiki/",
+		"http": "http://example.com/",
+	}
+
+	for value, expected := range map[string]struct {
+		iri  curie.IRI
+		kind curie.Kind
+	}{
+		"[wiki:CURIE]":         {"wiki:CURIE", curie.KindSafeCURIE},
+		"[http://x/y]":         {"http://x/y", curie.KindSafeCURIE},
+		" wiki:CURIE ":         {"wiki:CURIE", curie.KindCURIE},
+		"http://x/y":           {"http://x/y", curie.KindCURIE},
+		"https://x/y":          {"https://x/y", curie.KindIRI},
+		"mailto:a@example.com": {"mailto:a@example.com", curie.KindIRI},
+		"_:b0":                 {"_:b0", curie.KindCURIE},
+		"[_:b0]":               {"_:b0", curie.KindSafeCURIE},
+		"CURIE":                {"CURIE", curie.KindCURIE},
+		"wiki:Talk:CURIE":      {"wiki:Talk:CURIE", curie.KindCURIE},
+		"[wiki:Talk:CURIE]":    {"wiki:Talk:CURIE", curie.KindSafeCURIE},
+		"_:b0:x":               {"_:b0:x", curie.KindCURIE},
+	} {
+		t.Run(fmt.Sprintf("(%s)", value), func(t *testing.T) {
+			iri, kind, err := curie.Resolve(prefixes, value)
+
+			it.Then(t).Should(
+				it.Nil(err),
+				it.Equal(iri, expected.iri),
+				it.Equal(kind, expected.kind),
+			)
+		})
+	}
+}
+
+func TestResolveFail(t *testing.T) {
+	prefixes := curie.Namespaces{
+		"wiki": "http://en.wikipedia.org/wiki/",
+	}
+
+	for value, expected := range map[string]error{
+		"[wikk:CURIE]": curie.ErrUnknownPrefix,
+		"[wiki:a b]":   curie.ErrInvalidReference,
+		"[wiki:CURIE":  curie.ErrInvalidSafeCURIE,
+		"1x:CURIE":     curie.ErrUnknownPrefix,
+		"wiki:a b":     curie.ErrInvalidReference,
+		"https://x y":  curie.ErrInvalidReference,
+		"":             curie.ErrInvalidReference,
+		" ":            curie.ErrInvalidReference,
+		"[wikk:a:b]":   curie.ErrUnknownPrefix,
+	} {
+		t.Run(fmt.Sprintf("(%s)", value), func(t *testing.T) {
+			_, _, err := curie.Resolve(prefixes, value)
+
+			it.Then(t).Should(
+				it.True(errors.Is(err, expected)),
+			)
+		})
+	}
+
+	t.Run("Suggestions", func(t *testing.T) {
+		_, _, err := curie.Resolve(prefixes, "[wikk:CURIE]")
+
+		var e *curie.UnknownPrefixError
+		it.Then(t).Should(
+			it.True(errors.As(err, &e)),
+			it.Equal(e.Prefix, "wikk"),
+			it.Seq(e.Suggestions).Equal("wiki"),
+		)
+	})
+}
+
+func TestResolveList(t *testing.T) {
+	prefixes := curie.Namespaces{
+		"foaf": "http://xmlns.com/foaf/0.1/",
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		iris, err := curie.ResolveList(prefixes, " foaf:name  [foaf:knows]\thttp://schema.org/name ")
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Seq(iris).Equal("foaf:name", "foaf:knows", "http://schema.org/name"),
+		)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		iris, err := curie.ResolveList(prefixes, "foaf:name [ex:x]")
+
+		it.Then(t).Should(
+			it.True(errors.Is(err, curie.ErrUnknownPrefix)),
+			it.Seq(iris).Equal("foaf:name"),
+		)
+	})
+}
@@ -0,0 +1,195 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Value implements driver.Valuer, IRI is stored as compact string
+func (iri IRI) Value() (driver.Value, error) { return string(iri), nil }
+
+// Scan implements sql.Scanner, NULL is scanned as Empty
+func (iri *IRI) Scan(src any) error {
+	val, err := scanString(src)
+	if err != nil {
+		return err
+	}
+
+	*iri = IRI(val)
+	return nil
+}
+
+// NullIRI is IRI that may be NULL, it distinguishes NULL from Empty
+type NullIRI struct {
+	IRI   IRI
+	Valid bool // Valid is true if IRI is not NULL
+}
+
+// Value implements driver.Valuer
+func (n NullIRI) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	return string(n.IRI), nil
+}
+
+// Scan implements sql.Scanner
+func (n *NullIRI) Scan(src any) error {
+	if src == nil {
+		n.IRI, n.Valid = Empty, false
+		return nil
+	}
+
+	err := n.IRI.Scan(src)
+	n.Valid = err == nil
+	return err
+}
+
+// SQLCodec stores IRI as expanded URI using prefixes, the stored URI is
+// compacted back to IRI when it is scanned.
+//
+//	codec := curie.SQLCodec{Prefixes: prefixes}
+//	db.Exec("INSERT INTO t (id) VALUES (?)", codec.Wrap(&iri))
+//	db.QueryRow("SELECT id FROM t").Scan(codec.Wrap(&iri))
+type SQLCodec struct {
+	Prefixes Prefixes
+}
+
+// Value returns expanded URI, Empty is stored as empty string
+func (c SQLCodec) Value(iri IRI) (driver.Value, error) {
+	if len(iri) == 0 {
+		return "", nil
+	}
+
+	return URI(c.prefixes(), iri), nil
+}
+
+// Scan compacts stored URI, NULL is scanned as Empty
+func (c SQLCodec) Scan(src any, iri *IRI) error {
+	val, err := scanString(src)
+	if err != nil {
+		return err
+	}
+
+	*iri = FromURI(c.prefixes(), val)
+	return nil
+}
+
+func (c SQLCodec) prefixes() Prefixes {
+	if c.Prefixes == nil {
+		return Namespaces{}
+	}
+	return c.Prefixes
+}
+
+// Wrap binds IRI with the codec, the result implements sql.Scanner and
+// driver.Valuer.
+func (c SQLCodec) Wrap(iri *IRI) *SQLValue { return &SQLValue{Codec: c, IRI: iri} }
+
+// SQLValue is IRI bound with the codec
+type SQLValue struct {
+	Codec SQLCodec
+	IRI   *IRI
+}
+
+// Value implements driver.Valuer using the codec
+func (v SQLValue) Value() (driver.Value, error) {
+	if v.IRI == nil {
+		return nil, nil
+	}
+	return v.Codec.Value(*v.IRI)
+}
+
+// Scan implements sql.Scanner using the codec
+func (v SQLValue) Scan(src any) error {
+	if v.IRI == nil {
+		return fmt.Errorf("curie: cannot scan into nil IRI")
+	}
+	return v.Codec.Scan(src, v.IRI)
+}
+
+//------------------------------------------------------------------------------
+
+// SQLProfile declares the codec used by ExpandedSQL field type
+//
+//	type Storage struct{}
+//
+//	func (Storage) SQLCodec() curie.SQLCodec {
+//		return curie.SQLCodec{Prefixes: prefixes}
+//	}
+type SQLProfile interface {
+	SQLCodec() SQLCodec
+}
+
+// ExpandedSQL is IRI stored as expanded URI using the codec of the profile
+//
+//	type Person struct {
+//		ID curie.ExpandedSQL[Storage] `db:"id"`
+//	}
+type ExpandedSQL[T SQLProfile] IRI
+
+// ToIRI returns the identity
+func (iri ExpandedSQL[T]) ToIRI() IRI { return IRI(iri) }
+
+// Value implements driver.Valuer, the expanded URI is stored
+func (iri ExpandedSQL[T]) Value() (driver.Value, error) {
+	var profile T
+	return profile.SQLCodec().Value(IRI(iri))
+}
+
+// Scan implements sql.Scanner, the stored URI is compacted
+func (iri *ExpandedSQL[T]) Scan(src any) error {
+	var profile T
+	return profile.SQLCodec().Scan(src, (*IRI)(iri))
+}
+
+// NullExpandedSQL is ExpandedSQL that may be NULL
+type NullExpandedSQL[T SQLProfile] struct {
+	IRI   IRI
+	Valid bool // Valid is true if IRI is not NULL
+}
+
+// Value implements driver.Valuer
+func (n NullExpandedSQL[T]) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	var profile T
+	return profile.SQLCodec().Value(n.IRI)
+}
+
+// Scan implements sql.Scanner
+func (n *NullExpandedSQL[T]) Scan(src any) error {
+	if src == nil {
+		n.IRI, n.Valid = Empty, false
+		return nil
+	}
+
+	var profile T
+	err := profile.SQLCodec().Scan(src, &n.IRI)
+	n.Valid = err == nil
+	return err
+}
+
+func scanString(src any) (string, error) {
+	switch v := src.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	default:
+		return "", fmt.Errorf("curie: cannot scan %T into IRI", src)
+	}
+}
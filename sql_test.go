@@ -0,0 +1,128 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/fogfish/curie/v2"
+	"github.com/fogfish/it/v2"
+)
+
+var (
+	_ sql.Scanner   = (*curie.IRI)(nil)
+	_ driver.Valuer = curie.IRI("")
+	_ sql.Scanner   = (*curie.NullIRI)(nil)
+	_ driver.Valuer = curie.NullIRI{}
+	_ sql.Scanner   = (*curie.SQLValue)(nil)
+	_ driver.Valuer = curie.SQLValue{}
+	_ sql.Scanner   = (*curie.ExpandedSQL[storage])(nil)
+	_ driver.Valuer = curie.ExpandedSQL[storage]("")
+	_ sql.Scanner   = (*curie.NullExpandedSQL[storage])(nil)
+	_ driver.Valuer = curie.NullExpandedSQL[storage]{}
+)
+
+func TestSQL(t *testing.T) {
+	t.Run("IRI", func(t *testing.T) {
+		var iri curie.IRI
+		val, err := curie.IRI("wiki:CURIE").Value()
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(val.(string), "wiki:CURIE"),
+			it.Nil(iri.Scan("wiki:CURIE")),
+			it.Equal(iri, "wiki:CURIE"),
+			it.Nil(iri.Scan([]byte("wiki:URI"))),
+			it.Equal(iri, "wiki:URI"),
+			it.Nil(iri.Scan(nil)),
+			it.Equal(iri, curie.Empty),
+		).ShouldNot(
+			it.Nil(iri.Scan(10)),
+		)
+	})
+
+	t.Run("NullIRI", func(t *testing.T) {
+		var null, empty curie.NullIRI
+		val1, err1 := curie.NullIRI{}.Value()
+		val2, err2 := curie.NullIRI{Valid: true}.Value()
+
+		it.Then(t).Should(
+			it.Nil(err1),
+			it.Nil(val1),
+			it.Nil(err2),
+			it.Equal(val2.(string), ""),
+			it.Nil(null.Scan(nil)),
+			it.True(!null.Valid),
+			it.Nil(empty.Scan("")),
+			it.True(empty.Valid),
+			it.Equal(empty.IRI, curie.Empty),
+		)
+	})
+
+	t.Run("Codec", func(t *testing.T) {
+		var iri, empty curie.IRI
+		codec := curie.SQLCodec{Prefixes: codecPrefixes}
+		val1, err1 := codec.Wrap(&empty).Value()
+		val2, err2 := codec.Value("wiki:CURIE")
+		val3, err3 := curie.SQLValue{Codec: codec}.Value()
+
+		it.Then(t).Should(
+			it.Nil(err1),
+			it.Equal(val1.(string), ""),
+			it.Nil(err2),
+			it.Equal(val2.(string), "http://en.wikipedia.org/wiki/CURIE"),
+			it.Nil(err3),
+			it.Nil(val3),
+			it.Nil(codec.Wrap(&iri).Scan([]byte("http://en.wikipedia.org/wiki/CURIE"))),
+			it.Equal(iri, "wiki:CURIE"),
+			it.Nil(codec.Wrap(&iri).Scan(nil)),
+			it.Equal(iri, curie.Empty),
+			it.True(curie.SQLValue{Codec: codec}.Scan("wiki:CURIE") != nil),
+		)
+	})
+
+	t.Run("ExpandedSQL", func(t *testing.T) {
+		var iri curie.ExpandedSQL[storage]
+		val, err := curie.ExpandedSQL[storage]("wiki:CURIE").Value()
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(val.(string), "http://en.wikipedia.org/wiki/CURIE"),
+			it.Nil(iri.Scan("http://en.wikipedia.org/wiki/CURIE")),
+			it.Equal(iri, "wiki:CURIE"),
+			it.Equal(iri.ToIRI(), curie.IRI("wiki:CURIE")),
+		)
+	})
+
+	t.Run("NullExpandedSQL", func(t *testing.T) {
+		var null, iri curie.NullExpandedSQL[storage]
+		val1, err1 := curie.NullExpandedSQL[storage]{}.Value()
+		val2, err2 := curie.NullExpandedSQL[storage]{IRI: "wiki:CURIE", Valid: true}.Value()
+
+		it.Then(t).Should(
+			it.Nil(err1),
+			it.Nil(val1),
+			it.Nil(err2),
+			it.Equal(val2.(string), "http://en.wikipedia.org/wiki/CURIE"),
+			it.Nil(null.Scan(nil)),
+			it.True(!null.Valid),
+			it.Nil(iri.Scan("http://en.wikipedia.org/wiki/CURIE")),
+			it.True(iri.Valid),
+			it.Equal(iri.IRI, "wiki:CURIE"),
+		)
+	})
+}
+
+type storage struct{}
+
+func (storage) SQLCodec() curie.SQLCodec {
+	return curie.SQLCodec{Prefixes: codecPrefixes}
+}
@@ -0,0 +1,274 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidTemplate is returned when expansion of prefix is invalid URI template
+var ErrInvalidTemplate = errors.New("invalid URI template")
+
+// uriTemplate is the subset of RFC 6570 URI Template (level 2), where
+// each expression has single variable: simple {var}, reserved {+var} or
+// fragment {#var} expansion. Segments of CURIE reference are assigned to
+// variables in order, the last variable takes the rest of reference.
+//
+//	gh:fogfish/curie × https://github.com/{owner}/{repo} ⟼ https://github.com/fogfish/curie
+type uriTemplate struct {
+	literal string // literal prefix of the template
+	parts   []templatePart
+	vars    int
+}
+
+type templatePart struct {
+	literal string
+	op      byte // ' ' for simple, '+' for reserved or '#' for fragment expansion
+}
+
+// checks if expansion of prefix is URI template
+func isTemplate(s string) bool { return strings.IndexByte(s, '{') != -1 }
+
+// templated is implemented by prefixes that keep parsed URI templates
+type templated interface {
+	template(prefix, iri string) (*uriTemplate, bool)
+}
+
+// templateOf returns URI template of the prefix expansion, the template kept
+// by prefixes is used if it is parsed from the expansion, otherwise the
+// expansion is parsed.
+func templateOf(prefixes Prefixes, prefix, iri string) (*uriTemplate, error) {
+	if p, ok := prefixes.(templated); ok {
+		if t, has := p.template(prefix, iri); has {
+			return t, nil
+		}
+	}
+
+	return parseTemplate(iri)
+}
+
+func parseTemplate(s string) (*uriTemplate, error) {
+	t := &uriTemplate{literal: s[:strings.IndexByte(s+"{", '{')]}
+
+	for len(s) > 0 {
+		at := strings.IndexByte(s, '{')
+		if at == -1 {
+			at = len(s)
+		}
+		if strings.IndexByte(s[:at], '}') != -1 {
+			return nil, fmt.Errorf("%w: unexpected }", ErrInvalidTemplate)
+		}
+		if at > 0 {
+			t.parts = append(t.parts, templatePart{literal: s[:at]})
+			s = s[at:]
+			continue
+		}
+
+		end := strings.IndexByte(s, '}')
+		if end == -1 {
+			return nil, fmt.Errorf("%w: unterminated expression", ErrInvalidTemplate)
+		}
+
+		expr, op := s[1:end], byte(' ')
+		if len(expr) > 0 && (expr[0] == '+' || expr[0] == '#') {
+			expr, op = expr[1:], expr[0]
+		}
+		if !isVarName(expr) {
+			return nil, fmt.Errorf("%w: unsupported expression {%s}", ErrInvalidTemplate, s[1:end])
+		}
+
+		if n := len(t.parts); n > 0 && t.parts[n-1].op != 0 && op != '#' {
+			return nil, fmt.Errorf("%w: adjacent expressions", ErrInvalidTemplate)
+		}
+
+		t.parts = append(t.parts, templatePart{op: op})
+		t.vars++
+		s = s[end+1:]
+	}
+
+	return t, nil
+}
+
+// varname = varchar *( ["."] varchar ), varchar = ALPHA / DIGIT / "_"
+func isVarName(s string) bool {
+	if len(s) == 0 || s[0] == '.' || s[len(s)-1] == '.' {
+		return false
+	}
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') || c == '_' || c == '.') {
+			return false
+		}
+	}
+	return true
+}
+
+// expand template using segments of reference
+func (t *uriTemplate) expand(ref string) string {
+	values := strings.SplitN(ref, "/", t.vars)
+	if len(ref) == 0 {
+		values = nil
+	}
+
+	var b strings.Builder
+	i := 0
+	for _, part := range t.parts {
+		if part.op == 0 {
+			b.WriteString(part.literal)
+			continue
+		}
+
+		if i < len(values) {
+			if part.op == '#' {
+				b.WriteByte('#')
+			}
+			escapeTemplateValue(&b, values[i], part.op != ' ')
+		}
+		i++
+	}
+
+	return b.String()
+}
+
+// percent-encodes the value, reserved characters are kept for
+// reserved and fragment expansions
+func escapeTemplateValue(b *strings.Builder, s string, reserved bool) {
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r != utf8.RuneError && isIUnreserved(r):
+			b.WriteString(s[i : i+size])
+		case reserved && r < utf8.RuneSelf && checkReserved(byte(r)):
+			b.WriteByte(byte(r))
+		case reserved && r == '%' && i+2 < len(s) && ishex(s[i+1]) && ishex(s[i+2]):
+			b.WriteString(s[i : i+3])
+			size = 3
+		default:
+			for _, c := range []byte(s[i : i+size]) {
+				b.WriteByte('%')
+				b.WriteByte(upperhex[c>>4])
+				b.WriteByte(upperhex[c&15])
+			}
+		}
+		i += size
+	}
+}
+
+// match URI against template, returns reference composed of variables
+func (t *uriTemplate) match(uri string) (string, bool) {
+	values := make([]string, 0, t.vars)
+	defined := true
+
+	for i, part := range t.parts {
+		if part.op == 0 {
+			if !strings.HasPrefix(uri, part.literal) {
+				return "", false
+			}
+			uri = uri[len(part.literal):]
+			continue
+		}
+
+		if part.op == '#' {
+			if !strings.HasPrefix(uri, "#") {
+				defined = false
+				continue
+			}
+			uri = uri[1:]
+		}
+
+		end := len(uri)
+		if i+1 < len(t.parts) {
+			next := t.parts[i+1]
+			if next.op == '#' {
+				end = strings.IndexByte(uri+"#", '#')
+			} else if end = strings.Index(uri, next.literal); end == -1 {
+				return "", false
+			}
+		}
+
+		val, ok := unescapeTemplateValue(uri[:end], part.op != ' ')
+		if !ok || !defined {
+			return "", false
+		}
+		if len(values) < t.vars-1 && strings.IndexByte(val, '/') != -1 {
+			return "", false
+		}
+
+		values = append(values, val)
+		uri = uri[end:]
+	}
+
+	if len(uri) != 0 {
+		return "", false
+	}
+
+	return strings.Join(values, "/"), true
+}
+
+// validates and decodes the value of expression
+func unescapeTemplateValue(s string, reserved bool) (string, bool) {
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == '%':
+			if i+2 >= len(s) || !ishex(s[i+1]) || !ishex(s[i+2]) {
+				return "", false
+			}
+			size = 3
+		case r != utf8.RuneError && isIUnreserved(r):
+		case reserved && r < utf8.RuneSelf && checkReserved(byte(r)):
+		default:
+			return "", false
+		}
+		i += size
+	}
+
+	if reserved {
+		return s, true
+	}
+
+	val, err := url.PathUnescape(s)
+	return val, err == nil
+}
+
+//------------------------------------------------------------------------------
+
+// prefix which expansion is URI template
+type prefixTemplate struct {
+	prefix   string
+	template *uriTemplate
+}
+
+// matches URI against templates, the template competes with the literal
+// expansion of length n using longest match of their literal prefixes
+func matchTemplates(templates []prefixTemplate, uri string, prefix string, n int) (IRI, bool) {
+	var (
+		iri   IRI
+		found bool
+	)
+
+	for _, t := range templates {
+		lit := len(t.template.literal)
+		if lit < n || (lit == n && !preferred(t.prefix, prefix)) || !strings.HasPrefix(uri, t.template.literal) {
+			continue
+		}
+
+		if ref, ok := t.template.match(uri); ok {
+			iri, found = compactRef(t.prefix, ref), true
+			prefix, n = t.prefix, lit
+		}
+	}
+
+	return iri, found
+}
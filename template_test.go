@@ -0,0 +1,90 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/fogfish/curie/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestTemplate(t *testing.T) {
+	ns := curie.Namespaces{
+		"gh":     "https://github.com/{owner}/{repo}",
+		"id":     "https://x.org/lookup?id={id}",
+		"doc":    "https://docs.example.com/{+path}",
+		"sec":    "https://example.com/doc/{page}{#section}",
+		"ex":     "https://example.com/",
+		"github": "https://github.com/",
+	}
+
+	registry := curie.NewRegistry()
+	for prefix, iri := range ns {
+		it.Then(t).Should(it.Nil(registry.Register(prefix, iri)))
+	}
+
+	for _, prefixes := range []curie.Prefixes{ns, curie.NewTrie(ns), registry, curie.Overlay(registry, ns)} {
+		t.Run(fmt.Sprintf("%T", prefixes), func(t *testing.T) {
+			for iri, uri := range map[curie.IRI]string{
+				"gh:fogfish/curie":   "https://github.com/fogfish/curie",
+				"gh:fogfish/a/b":     "https://github.com/fogfish/a%2Fb",
+				"id:a b":             "https://x.org/lookup?id=a%20b",
+				"id:a/b?c":           "https://x.org/lookup?id=a%2Fb%3Fc",
+				"doc:a/b?c=d":        "https://docs.example.com/a/b?c=d",
+				"sec:intro/overview": "https://example.com/doc/intro#overview",
+				"id:Ῥόδος":           "https://x.org/lookup?id=%E1%BF%AC%CF%8C%CE%B4%CE%BF%CF%82",
+				"github:fogfish":     "https://github.com/fogfish",
+				"ex:a":               "https://example.com/a",
+			} {
+				t.Run(string(iri), func(t *testing.T) {
+					it.Then(t).Should(
+						it.Equal(curie.URI(prefixes, iri), uri),
+						it.Equal(curie.FromURI(prefixes, uri), iri),
+					)
+				})
+			}
+
+			it.Then(t).Should(
+				it.Equal(curie.URI(prefixes, "sec:intro"), "https://example.com/doc/intro"),
+				it.Equal(curie.FromURI(prefixes, "https://example.com/doc/intro"), "sec:intro"),
+				it.Equal(curie.FromURI(prefixes, "https://x.org/lookup?q=1"), "https://x.org/lookup?q=1"),
+			)
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, template := range []string{
+			"https://example.com/{a",
+			"https://example.com/{a,b}",
+			"https://example.com/{a}{b}",
+			"https://example.com/{?a}",
+		} {
+			ns := curie.Namespaces{"ex": template}
+			_, err1 := curie.URL(ns, "ex:a")
+			_, err2 := curie.URL(curie.NewTrie(ns), "ex:a")
+			issues := curie.Lint(ns)
+
+			var flag curie.Namespaces
+			err3 := flag.Set("ex=" + template)
+
+			it.Then(t).Should(
+				it.True(errors.Is(err1, curie.ErrInvalidTemplate)),
+				it.True(errors.Is(err2, curie.ErrInvalidTemplate)),
+				it.True(errors.Is(err3, curie.ErrInvalidTemplate)),
+				it.Equal(curie.FromURI(ns, "https://example.com/a"), "https://example.com/a"),
+				it.Equal(curie.FromURI(curie.NewTrie(ns), "https://example.com/a"), "https://example.com/a"),
+				it.Equal(len(issues), 1),
+				it.True(errors.Is(issues[0], curie.ErrInvalidTemplate)),
+			)
+		}
+	})
+}
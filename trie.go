@@ -0,0 +1,147 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie
+
+import "github.com/fogfish/curie/v2/internal/trie"
+
+// Trie is immutable collection of prefixes indexed by prefix tree.
+// It is a drop-in replacement of Namespaces for large collections,
+// the compaction is O(len(uri)) using the longest matching expansion.
+// Prefixes sharing the same expansion are resolved deterministically,
+// the shortest prefix wins, then lexicographically smallest one.
+//
+//	prefixes := curie.NewTrie(curie.Namespaces{
+//		"ex":  "https://example.com/",
+//		"exa": "https://example.com/a/",
+//	})
+//	curie.FromURI(prefixes, "https://example.com/a/b") ⟼ exa:b
+type Trie struct {
+	ns        *trie.Trie // prefix ⟼ expansion
+	trie      *trie.Trie // expansion ⟼ prefixes
+	templates []prefixTemplate
+}
+
+var _ Prefixes = (*Trie)(nil)
+
+// NewTrie builds prefix tree from namespaces
+func NewTrie(ns Namespaces) *Trie {
+	t := &Trie{
+		ns:   trie.New(preferred),
+		trie: trie.New(preferred),
+	}
+
+	for prefix, iri := range ns {
+		t.ns.Put(prefix, iri)
+		switch {
+		case prefix == BlankNode:
+		case isTemplate(iri):
+			if tmpl, err := parseTemplate(iri); err == nil {
+				t.templates = append(t.templates, prefixTemplate{prefix, tmpl})
+			}
+		default:
+			t.trie.Put(iri, prefix)
+		}
+	}
+
+	return t
+}
+
+// with returns copy of the tree with the prefix, the copy shares
+// unchanged branches with the original tree.
+func (t *Trie) with(prefix, iri string) *Trie {
+	c := &Trie{ns: t.ns.With(prefix, iri), trie: t.trie, templates: t.templates}
+
+	switch {
+	case prefix == BlankNode:
+	case isTemplate(iri):
+		if tmpl, err := parseTemplate(iri); err == nil {
+			c.templates = append(t.templates[:len(t.templates):len(t.templates)], prefixTemplate{prefix, tmpl})
+		}
+	default:
+		c.trie = t.trie.With(iri, prefix)
+	}
+
+	return c
+}
+
+// without returns copy of the tree without the prefix
+func (t *Trie) without(prefix string) *Trie {
+	iri, has := t.Lookup(prefix)
+	if !has {
+		return t
+	}
+
+	c := &Trie{ns: t.ns.Without(prefix, iri), trie: t.trie.Without(iri, prefix)}
+	for _, tmpl := range t.templates {
+		if tmpl.prefix != prefix {
+			c.templates = append(c.templates, tmpl)
+		}
+	}
+
+	return c
+}
+
+// prefixOf returns the preferred prefix bound to the expansion
+func (t *Trie) prefixOf(iri string) (string, bool) {
+	if seq := t.trie.Get(iri); len(seq) != 0 {
+		return seq[0], true
+	}
+
+	for _, tmpl := range t.templates {
+		if val, _ := t.Lookup(tmpl.prefix); val == iri {
+			return tmpl.prefix, true
+		}
+	}
+
+	return "", false
+}
+
+// template returns parsed URI template of the prefix bound to the expansion
+func (t *Trie) template(prefix, iri string) (*uriTemplate, bool) {
+	if val, has := t.Lookup(prefix); !has || val != iri {
+		return nil, false
+	}
+
+	for _, tmpl := range t.templates {
+		if tmpl.prefix == prefix {
+			return tmpl.template, true
+		}
+	}
+
+	return nil, false
+}
+
+// Create new URI using prefix tree
+func (t *Trie) Create(uri string) IRI {
+	n, prefix, has := t.trie.Match(uri)
+	if !has {
+		n = -1
+	}
+
+	if iri, has := matchTemplates(t.templates, uri, prefix, n); has {
+		return iri
+	}
+
+	if n == -1 {
+		return IRI(uri)
+	}
+
+	return compact(prefix, uri[n:])
+}
+
+// Lookup prefix in the tree
+func (t *Trie) Lookup(prefix string) (string, bool) {
+	if seq := t.ns.Get(prefix); len(seq) != 0 {
+		return seq[0], true
+	}
+	return "", false
+}
+
+// Range iterates over prefixes of the tree
+func (t *Trie) Range(f func(prefix, iri string) bool) { t.ns.Walk(f) }
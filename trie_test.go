@@ -0,0 +1,65 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/fogfish/curie/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestLongestMatch(t *testing.T) {
+	ns := curie.Namespaces{
+		"ex":   "https://example.com/",
+		"exa":  "https://example.com/a/",
+		"wiki": "http://en.wikipedia.org/wiki/",
+		"w":    "http://en.wikipedia.org/wiki/",
+		"v":    "http://en.wikipedia.org/wiki/",
+	}
+
+	for _, prefixes := range []curie.Prefixes{ns, curie.NewTrie(ns)} {
+		t.Run(fmt.Sprintf("%T", prefixes), func(t *testing.T) {
+			for uri, expected := range map[string]curie.IRI{
+				"https://example.com/":                   "ex:",
+				"https://example.com/b":                  "ex:b",
+				"https://example.com/a":                  "ex:a",
+				"https://example.com/a/":                 "exa:",
+				"https://example.com/a/b/c":              "exa:b/c",
+				"https://example.org/":                   "https://example.org/",
+				"http://en.wikipedia.org/wiki/Ῥ":         "v:Ῥ",
+				"http://en.wikipedia.org/wiki/%E1%BF%AC": "v:Ῥ",
+			} {
+				// repeat to detect random iteration order
+				for i := 0; i < 10; i++ {
+					it.Then(t).Should(
+						it.Equal(curie.FromURI(prefixes, uri), expected),
+					)
+				}
+			}
+		})
+	}
+}
+
+func TestTrieLookup(t *testing.T) {
+	ns := curie.Namespaces{"ex": "https://example.com/"}
+	prefixes := curie.NewTrie(ns)
+	ns["ex"] = "https://example.org/"
+
+	uri1, has1 := prefixes.Lookup("ex")
+	_, has2 := prefixes.Lookup("ab")
+
+	it.Then(t).Should(
+		it.True(has1),
+		it.Equal(uri1, "https://example.com/"),
+		it.True(!has2),
+		it.Equal(curie.URI(prefixes, "ex:a/b"), "https://example.com/a/b"),
+	)
+}
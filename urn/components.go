@@ -0,0 +1,180 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package urn
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Components of URN as defined by RFC 8141
+//
+//	namestring    = assigned-name [ rq-components ] [ "#" f-component ]
+//	assigned-name = "urn" ":" NID ":" NSS
+//	rq-components = [ "?+" r-component ] [ "?=" q-component ]
+type Components struct {
+	NID string // namespace identifier
+	NSS string // namespace specific string
+	R   string // r-component, resolution parameters
+	Q   string // q-component, query parameters
+	F   string // f-component, fragment
+}
+
+// URN composes components into URN
+func (c Components) URN() URN {
+	var b strings.Builder
+	b.Grow(4 + len(c.NID) + len(c.NSS) + len(c.R) + len(c.Q) + len(c.F) + 7)
+
+	b.WriteString("urn:")
+	b.WriteString(c.NID)
+	if len(c.NSS) > 0 {
+		b.WriteString(":")
+		b.WriteString(c.NSS)
+	}
+	if len(c.R) > 0 {
+		b.WriteString("?+")
+		b.WriteString(c.R)
+	}
+	if len(c.Q) > 0 {
+		b.WriteString("?=")
+		b.WriteString(c.Q)
+	}
+	if len(c.F) > 0 {
+		b.WriteString("#")
+		b.WriteString(c.F)
+	}
+
+	return URN(b.String())
+}
+
+// Components decomposes URN into components
+func (urn URN) Components() Components { return Decompose(urn) }
+
+// Decompose URN into components
+func Decompose(urn URN) Components {
+	if len(urn) < 5 {
+		return Components{}
+	}
+
+	var c Components
+	s := string(urn[4:])
+
+	if n := strings.IndexByte(s, '#'); n != -1 {
+		s, c.F = s[:n], s[n+1:]
+	}
+
+	if n := strings.Index(s, "?="); n != -1 {
+		s, c.Q = s[:n], s[n+2:]
+	}
+
+	if n := strings.Index(s, "?+"); n != -1 {
+		s, c.R = s[:n], s[n+2:]
+	}
+
+	if n := strings.IndexByte(s, ':'); n != -1 {
+		c.NID, c.NSS = s[:n], s[n+1:]
+	} else {
+		c.NID = s
+	}
+
+	return c
+}
+
+// rebuild URN with new NSS, other components are preserved
+func withNSS(urn URN, nss string) URN {
+	c := Decompose(urn)
+	c.NSS = nss
+	return c.URN()
+}
+
+// Return r-component of URN
+func (urn URN) R() string { return R(urn) }
+
+// Return r-component of URN
+func R(urn URN) string { return Decompose(urn).R }
+
+// Return q-component of URN
+func (urn URN) Q() string { return Q(urn) }
+
+// Return q-component of URN
+func Q(urn URN) string { return Decompose(urn).Q }
+
+// Return f-component of URN
+func (urn URN) F() string { return F(urn) }
+
+// Return f-component of URN
+func F(urn URN) string { return Decompose(urn).F }
+
+// WithR replaces r-component of URN
+func (urn URN) WithR(r string) URN { return WithR(urn, r) }
+
+// WithR replaces r-component of URN
+func WithR(urn URN, r string) URN {
+	c := Decompose(urn)
+	c.R = r
+	return c.URN()
+}
+
+// WithQ replaces q-component of URN
+func (urn URN) WithQ(q string) URN { return WithQ(urn, q) }
+
+// WithQ replaces q-component of URN
+func WithQ(urn URN, q string) URN {
+	c := Decompose(urn)
+	c.Q = q
+	return c.URN()
+}
+
+// WithF replaces f-component of URN
+func (urn URN) WithF(f string) URN { return WithF(urn, f) }
+
+// WithF replaces f-component of URN
+func WithF(urn URN, f string) URN {
+	c := Decompose(urn)
+	c.F = f
+	return c.URN()
+}
+
+// URL converts URN to url.URL in opaque form
+//
+//	urn:example:a?+r?=q#f ⟼ url.URL{Scheme: "urn", Opaque: "example:a", RawQuery: "+r?=q", Fragment: "f"}
+func (urn URN) URL() *url.URL { return URL(urn) }
+
+// URL converts URN to url.URL in opaque form
+//
+//	urn:example:a?+r?=q#f ⟼ url.URL{Scheme: "urn", Opaque: "example:a", RawQuery: "+r?=q", Fragment: "f"}
+func URL(urn URN) *url.URL {
+	if len(urn) == 0 {
+		return new(url.URL)
+	}
+
+	c := Decompose(urn)
+	u := &url.URL{Scheme: "urn", Opaque: c.NID}
+	if len(c.NSS) > 0 {
+		u.Opaque += ":" + c.NSS
+	}
+
+	switch {
+	case len(c.R) > 0 && len(c.Q) > 0:
+		u.RawQuery = "+" + c.R + "?=" + c.Q
+	case len(c.R) > 0:
+		u.RawQuery = "+" + c.R
+	case len(c.Q) > 0:
+		u.RawQuery = "=" + c.Q
+	}
+
+	if len(c.F) > 0 {
+		u.Fragment = c.F
+		if f, err := url.PathUnescape(c.F); err == nil {
+			u.Fragment, u.RawFragment = f, c.F
+		}
+	}
+
+	return u
+}
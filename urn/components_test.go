@@ -0,0 +1,103 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package urn_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/fogfish/curie/v2/urn"
+	"github.com/fogfish/it/v2"
+)
+
+func TestComponents(t *testing.T) {
+	for input, expected := range map[urn.URN]urn.Components{
+		"":                                 {},
+		"urn:example":                      {NID: "example"},
+		"urn:example:a:b":                  {NID: "example", NSS: "a:b"},
+		"urn:example:a:b?+r":               {NID: "example", NSS: "a:b", R: "r"},
+		"urn:example:a:b?=lang=en":         {NID: "example", NSS: "a:b", Q: "lang=en"},
+		"urn:example:a:b#sec":              {NID: "example", NSS: "a:b", F: "sec"},
+		"urn:example:a:b?+r?x?=q?y#f?z":    {NID: "example", NSS: "a:b", R: "r?x", Q: "q?y", F: "f?z"},
+		"urn:example:a:b?=lang=en#sec":     {NID: "example", NSS: "a:b", Q: "lang=en", F: "sec"},
+		"urn:example:a/b?+CCResolve:cc=uk": {NID: "example", NSS: "a/b", R: "CCResolve:cc=uk"},
+	} {
+		t.Run(fmt.Sprintf("(%s)", input), func(t *testing.T) {
+			c := input.Components()
+			schema, ref := input.Split()
+
+			it.Then(t).Should(
+				it.Equal(c, expected),
+				it.Equal(schema, expected.NID),
+				it.Equal(ref, expected.NSS),
+				it.Equal(input.R(), expected.R),
+				it.Equal(input.Q(), expected.Q),
+				it.Equal(input.F(), expected.F),
+			)
+
+			if len(input) != 0 {
+				it.Then(t).Should(
+					it.Equal(c.URN(), input),
+				)
+			}
+		})
+	}
+}
+
+func TestComponentsBuilder(t *testing.T) {
+	id := urn.URN("urn:example:a:b")
+
+	it.Then(t).Should(
+		it.Equal(id.WithR("r"), "urn:example:a:b?+r"),
+		it.Equal(id.WithQ("q"), "urn:example:a:b?=q"),
+		it.Equal(id.WithF("f"), "urn:example:a:b#f"),
+		it.Equal(id.WithF("f").WithQ("q").WithR("r"), "urn:example:a:b?+r?=q#f"),
+		it.Equal(id.WithF("f").WithQ("q").WithR("r").WithQ(""), "urn:example:a:b?+r#f"),
+	)
+}
+
+func TestComponentsHierarchy(t *testing.T) {
+	id := urn.URN("urn:example:a:b?=lang=en#sec")
+
+	it.Then(t).Should(
+		it.Equal(urn.Base(id), "b"),
+		it.Equal(urn.Path(id), "urn:example:a?=lang=en#sec"),
+		it.Equal(urn.Head(id), "a"),
+		it.Equal(urn.Tail(id), "urn:example:b?=lang=en#sec"),
+		it.Equal(urn.Join(id, "c"), "urn:example:a:b:c?=lang=en#sec"),
+		it.Equal(urn.Cut(id, 1), "urn:example:a?=lang=en#sec"),
+		it.Equal(urn.ToIRI(id), "example:a/b"),
+	)
+}
+
+func TestURL(t *testing.T) {
+	for input, expected := range map[urn.URN]string{
+		"":                        "",
+		"urn:example:a:b":         "urn:example:a:b",
+		"urn:example:a:b?+r":      "urn:example:a:b?+r",
+		"urn:example:a:b?=q":      "urn:example:a:b?=q",
+		"urn:example:a:b?+r?=q#f": "urn:example:a:b?+r?=q#f",
+		"urn:example:a:b#f%2Fg":   "urn:example:a:b#f%2Fg",
+	} {
+		t.Run(fmt.Sprintf("(%s)", input), func(t *testing.T) {
+			u := urn.URL(input)
+
+			it.Then(t).Should(
+				it.Equal(u.String(), expected),
+			)
+
+			if len(input) != 0 {
+				it.Then(t).Should(
+					it.Equal(u.Scheme, "urn"),
+					it.Equal(u.Opaque, "example:a:b"),
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package urn
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// String returns URN as string
+func (urn URN) String() string { return string(urn) }
+
+// MarshalText `URN ⟼ urn:schema:reference`, URN is validated
+func (urn URN) MarshalText() ([]byte, error) {
+	if err := validate(string(urn)); err != nil {
+		return nil, err
+	}
+
+	return []byte(urn), nil
+}
+
+// UnmarshalText `urn:schema:reference ⟼ URN`, URN is validated
+func (urn *URN) UnmarshalText(b []byte) error {
+	if err := validate(string(b)); err != nil {
+		return err
+	}
+
+	*urn = URN(b)
+	return nil
+}
+
+// MarshalBinary `URN ⟼ urn:schema:reference`
+func (urn URN) MarshalBinary() ([]byte, error) { return urn.MarshalText() }
+
+// UnmarshalBinary `urn:schema:reference ⟼ URN`
+func (urn *URN) UnmarshalBinary(b []byte) error { return urn.UnmarshalText(b) }
+
+// Set implements flag.Value, URN is validated
+//
+//	flag.Var(&urn, "id", "identity")
+func (urn *URN) Set(s string) error { return urn.UnmarshalText([]byte(s)) }
+
+// LogValue implements slog.LogValuer, URN is logged as group of schema and reference
+func (urn URN) LogValue() slog.Value {
+	schema, ref := Split(urn)
+	return slog.GroupValue(
+		slog.String("schema", schema),
+		slog.String("reference", ref),
+	)
+}
+
+// Format implements fmt.Formatter, other verbs are applied to the string
+//
+//	%s, %v  urn:schema:reference
+//	%q      "urn:schema:reference"
+//	%+v     [urn:schema:reference]
+//	%#v     urn.URN("urn:schema:reference")
+func (urn URN) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		fmt.Fprintf(f, "urn.URN(%q)", string(urn))
+	case verb == 'v' && f.Flag('+'):
+		if len(urn) > 0 {
+			fmt.Fprintf(f, fmt.FormatString(f, 's'), "["+string(urn)+"]")
+		} else {
+			fmt.Fprintf(f, fmt.FormatString(f, 's'), "")
+		}
+	default:
+		fmt.Fprintf(f, fmt.FormatString(f, verb), string(urn))
+	}
+}
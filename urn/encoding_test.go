@@ -0,0 +1,114 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package urn_test
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/fogfish/curie/v2/urn"
+	"github.com/fogfish/it/v2"
+)
+
+var (
+	_ encoding.TextMarshaler     = urn.URN("")
+	_ encoding.TextUnmarshaler   = (*urn.URN)(nil)
+	_ encoding.BinaryMarshaler   = urn.URN("")
+	_ encoding.BinaryUnmarshaler = (*urn.URN)(nil)
+	_ flag.Value                 = (*urn.URN)(nil)
+	_ slog.LogValuer             = urn.URN("")
+	_ fmt.Formatter              = urn.URN("")
+)
+
+func TestText(t *testing.T) {
+	t.Run("MapKey", func(t *testing.T) {
+		val := map[urn.URN]int{"urn:isbn:0451450523": 1}
+		b, err := json.Marshal(val)
+
+		var out map[urn.URN]int
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(string(b), `{"urn:isbn:0451450523":1}`),
+			it.Nil(json.Unmarshal(b, &out)),
+			it.Equiv(out, val),
+		).ShouldNot(
+			it.Nil(json.Unmarshal([]byte(`{"isbn:0451450523":1}`), &out)),
+		)
+	})
+
+	t.Run("Gob", func(t *testing.T) {
+		var b bytes.Buffer
+		var out urn.URN
+
+		it.Then(t).Should(
+			it.Nil(gob.NewEncoder(&b).Encode(urn.URN("urn:isbn:0451450523"))),
+			it.Nil(gob.NewDecoder(&b).Decode(&out)),
+			it.Equal(out, "urn:isbn:0451450523"),
+		)
+	})
+}
+
+func TestFlag(t *testing.T) {
+	var id urn.URN
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(&id, "id", "identity")
+	err := fs.Parse([]string{"-id", "urn:isbn:0451450523"})
+
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(id, "urn:isbn:0451450523"),
+		it.True(errors.Is(id.Set("isbn:0451450523"), urn.ErrInvalidScheme)),
+	)
+}
+
+func TestLogValue(t *testing.T) {
+	var b bytes.Buffer
+	log := slog.New(slog.NewJSONHandler(&b, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+
+	log.Info("test", "id", urn.URN("urn:isbn:0451450523"))
+
+	it.Then(t).Should(
+		it.Equal(b.String(), `{"level":"INFO","msg":"test","id":{"schema":"isbn","reference":"0451450523"}}`+"\n"),
+	)
+}
+
+func TestFormat(t *testing.T) {
+	id := urn.URN("urn:isbn:0451450523")
+
+	for format, expect := range map[string]string{
+		"%s":  "urn:isbn:0451450523",
+		"%v":  "urn:isbn:0451450523",
+		"%q":  `"urn:isbn:0451450523"`,
+		"%+v": "[urn:isbn:0451450523]",
+		"%#v": `urn.URN("urn:isbn:0451450523")`,
+		"%x":  "75726e3a6973626e3a30343531343530353233",
+		"%d":  "%!d(string=urn:isbn:0451450523)",
+	} {
+		t.Run(format, func(t *testing.T) {
+			it.Then(t).Should(
+				it.Equal(fmt.Sprintf(format, id), expect),
+			)
+		})
+	}
+}
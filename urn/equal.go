@@ -0,0 +1,84 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package urn
+
+import (
+	"strings"
+	"sync"
+)
+
+// Normalizer extends lexical equivalence with namespace specific rules.
+// It receives NSS after the generic normalization and returns its canonical form.
+type Normalizer func(nss string) string
+
+var normalizers sync.Map
+
+// RegisterNormalizer defines equivalence rules for the namespace (NID).
+// The nil Normalizer removes the rules.
+//
+//	urn.RegisterNormalizer("isbn", strings.ToUpper)
+func RegisterNormalizer(nid string, f Normalizer) {
+	nid = strings.ToLower(nid)
+	if f == nil {
+		normalizers.Delete(nid)
+		return
+	}
+
+	normalizers.Store(nid, f)
+}
+
+// Normalize URN to the canonical form, as defined by RFC 8141 section 3:
+// the scheme and NID are lower cased, percent-encoding is upper cased,
+// r-, q- and f- components are dropped. NID specific rules are applied after.
+//
+//	URN:ISBN:%3a0451450523 ⟼ urn:isbn:%3A0451450523
+func Normalize(urn URN) URN {
+	if len(urn) == 0 {
+		return Empty
+	}
+
+	c := Decompose(urn)
+	nid := strings.ToLower(c.NID)
+	nss := normalizePercentEncoding(c.NSS)
+
+	if f, has := normalizers.Load(nid); has {
+		nss = f.(Normalizer)(nss)
+	}
+
+	return Components{NID: nid, NSS: nss}.URN()
+}
+
+// Equal checks lexical equivalence of URNs
+func Equal(a, b URN) bool {
+	return Normalize(a) == Normalize(b)
+}
+
+func normalizePercentEncoding(s string) string {
+	if strings.IndexByte(s, '%') == -1 {
+		return s
+	}
+
+	b := []byte(s)
+	for i := 0; i < len(b)-2; i++ {
+		if b[i] == '%' && isHex(b[i+1]) && isHex(b[i+2]) {
+			b[i+1] = upper(b[i+1])
+			b[i+2] = upper(b[i+2])
+			i += 2
+		}
+	}
+
+	return string(b)
+}
+
+func upper(c byte) byte {
+	if 'a' <= c && c <= 'f' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
@@ -0,0 +1,63 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package urn_test
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/fogfish/curie/v2/urn"
+	"github.com/fogfish/it/v2"
+)
+
+func TestNormalize(t *testing.T) {
+	for input, expected := range map[urn.URN]urn.URN{
+		"":                              "",
+		"urn:isbn:0451450523":           "urn:isbn:0451450523",
+		"URN:ISBN:0451450523":           "urn:isbn:0451450523",
+		"urn:example:%3a%2fA":           "urn:example:%3A%2FA",
+		"urn:example:a%":                "urn:example:a%",
+		"urn:example:a?+r?=q#f":         "urn:example:a",
+		"urn:Example:CaseSensitive":     "urn:example:CaseSensitive",
+		"urn:example:%e1%bf%ac%cf%8c%c": "urn:example:%E1%BF%AC%CF%8C%c",
+	} {
+		t.Run(fmt.Sprintf("(%s)", input), func(t *testing.T) {
+			it.Then(t).Should(
+				it.Equal(urn.Normalize(input), expected),
+			)
+		})
+	}
+}
+
+func TestEqual(t *testing.T) {
+	it.Then(t).Should(
+		it.True(urn.Equal("urn:ISBN:0451450523", "urn:isbn:0451450523")),
+		it.True(urn.Equal("URN:example:%2f", "urn:example:%2F")),
+		it.True(urn.Equal("urn:example:a?=q#f", "urn:example:a")),
+		it.True(!urn.Equal("urn:example:A", "urn:example:a")),
+		it.True(!urn.Equal("urn:isbn:%30451450523", "urn:isbn:0451450523")),
+	)
+}
+
+func TestRegisterNormalizer(t *testing.T) {
+	urn.RegisterNormalizer("ISBN", func(nss string) string {
+		s, err := url.PathUnescape(nss)
+		if err != nil {
+			return nss
+		}
+		return s
+	})
+	defer urn.RegisterNormalizer("isbn", nil)
+
+	it.Then(t).Should(
+		it.True(urn.Equal("URN:isbn:%30451450523", "urn:ISBN:0451450523")),
+		it.True(!urn.Equal("urn:example:%30", "urn:example:0")),
+	)
+}
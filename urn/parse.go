@@ -0,0 +1,243 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package urn
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+//------------------------------------------------------------------------------
+//
+// Errors
+//
+//------------------------------------------------------------------------------
+
+// Errors returned by the parser, use errors.Is to distinguish them
+var (
+	ErrInvalidScheme    = errors.New("invalid scheme")
+	ErrInvalidNID       = errors.New("invalid NID")
+	ErrReservedNID      = errors.New("reserved NID")
+	ErrInvalidNSS       = errors.New("invalid NSS")
+	ErrInvalidComponent = errors.New("invalid component")
+)
+
+// SyntaxError describes malformed URN, the component and position of the failure
+type SyntaxError struct {
+	Input     string // input given to the parser
+	Component string // scheme, NID, NSS, r-component, q-component or f-component
+	Offset    int    // byte offset of the failure within the input
+	Reason    string // human readable reason
+	Err       error  // one of ErrInvalidNID, ErrInvalidNSS, ...
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("urn: %s %q at offset %d: %s", e.Err, e.Input, e.Offset, e.Reason)
+}
+
+func (e *SyntaxError) Unwrap() error { return e.Err }
+
+//------------------------------------------------------------------------------
+//
+// Parser
+//
+//------------------------------------------------------------------------------
+
+// Parse validates the string according to RFC 8141 and transforms it to URN.
+// The failure is reported as *SyntaxError.
+//
+//	namestring    = assigned-name [ rq-components ] [ "#" f-component ]
+//	assigned-name = "urn" ":" NID ":" NSS
+//	rq-components = [ "?+" r-component ] [ "?=" q-component ]
+func Parse(s string) (URN, error) {
+	p := parser{input: s}
+	if err := p.parse(); err != nil {
+		return Empty, err
+	}
+
+	return URN(s), nil
+}
+
+// validate URN for codecs, the namespace only form is accepted
+func validate(s string) error {
+	if len(s) == 0 {
+		return nil
+	}
+
+	p := parser{input: s, namespace: true}
+	return p.parse()
+}
+
+type parser struct {
+	input string
+	// accept namespace only form `urn:NID`, as produced by New(nid, "")
+	namespace bool
+}
+
+func (p *parser) fail(at int, component string, err error, reason string, args ...any) error {
+	return &SyntaxError{
+		Input:     p.input,
+		Component: component,
+		Offset:    at,
+		Reason:    fmt.Sprintf(reason, args...),
+		Err:       err,
+	}
+}
+
+func (p *parser) parse() error {
+	s := p.input
+	if len(s) < 4 || !strings.EqualFold(s[:4], "urn:") {
+		return p.fail(0, "scheme", ErrInvalidScheme, "expected urn:")
+	}
+
+	end := 4 + strings.IndexByte(s[4:]+":", ':')
+	if err := p.nid(4, end); err != nil {
+		return err
+	}
+
+	if end == len(s) && p.namespace {
+		return nil
+	}
+
+	if end == len(s) {
+		return p.fail(end, "NSS", ErrInvalidNSS, "missing NSS")
+	}
+
+	return p.components(end + 1)
+}
+
+// NID = (alphanum) 0*30(ldh) (alphanum)
+func (p *parser) nid(at, end int) error {
+	nid := p.input[at:end]
+
+	if len(nid) < 2 || len(nid) > 32 {
+		return p.fail(at, "NID", ErrInvalidNID, "length must be 2 - 32 characters")
+	}
+
+	for i := 0; i < len(nid); i++ {
+		c := nid[i]
+		switch {
+		case isAlphaNum(c):
+		case c == '-' && i != 0 && i != len(nid)-1:
+		default:
+			return p.fail(at+i, "NID", ErrInvalidNID, "illegal character %q", c)
+		}
+	}
+
+	// informal namespaces `urn-<number>`, RFC 8141 section 5.2
+	if len(nid) > 4 && strings.EqualFold(nid[:4], "urn-") {
+		num := nid[4:]
+		if num[0] == '0' {
+			return p.fail(at+4, "NID", ErrReservedNID, "informal namespace number has leading zero")
+		}
+		for i := 0; i < len(num); i++ {
+			if num[i] < '0' || num[i] > '9' {
+				return p.fail(at+4+i, "NID", ErrReservedNID, "informal namespace requires number")
+			}
+		}
+	}
+
+	// experimental namespaces `X-`, obsoleted by RFC 8141 section 5.2
+	if strings.EqualFold(nid[:2], "x-") {
+		return p.fail(at, "NID", ErrReservedNID, "experimental X- namespaces are not allowed")
+	}
+
+	return nil
+}
+
+// NSS [ "?+" r-component ] [ "?=" q-component ] [ "#" f-component ]
+func (p *parser) components(at int) error {
+	s := p.input
+
+	fc := len(s)
+	if n := strings.IndexByte(s[at:], '#'); n != -1 {
+		fc = at + n
+	}
+
+	qc := fc
+	if n := strings.Index(s[at:fc], "?="); n != -1 {
+		qc = at + n
+	}
+
+	rc := qc
+	if n := strings.Index(s[at:qc], "?+"); n != -1 {
+		rc = at + n
+	}
+
+	if err := p.scan(at, rc, "NSS", ErrInvalidNSS, false); err != nil {
+		return err
+	}
+
+	if rc < qc {
+		if err := p.scan(rc+2, qc, "r-component", ErrInvalidComponent, true); err != nil {
+			return err
+		}
+	}
+
+	if qc < fc {
+		if err := p.scan(qc+2, fc, "q-component", ErrInvalidComponent, true); err != nil {
+			return err
+		}
+	}
+
+	if fc < len(s) {
+		if err := p.scan(fc+1, len(s), "f-component", ErrInvalidComponent, true); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NSS         = pchar *(pchar / "/")
+// r-component = pchar *( pchar / "/" / "?" )
+// q-component = pchar *( pchar / "/" / "?" )
+// f-component = *( pchar / "/" / "?" )
+func (p *parser) scan(at, end int, component string, err error, query bool) error {
+	s := p.input
+
+	// f-component is the only one that might be empty or begin with any char
+	strict := component != "f-component"
+	if at == end && strict {
+		return p.fail(at, component, err, "must not be empty")
+	}
+
+	for i := at; i < end; i++ {
+		c := s[i]
+		switch {
+		case c == '%':
+			if i+2 >= end || !isHex(s[i+1]) || !isHex(s[i+2]) {
+				return p.fail(i, component, err, "malformed percent-encoding")
+			}
+			i += 2
+		case i == at && strict && (c == '/' || c == '?'):
+			return p.fail(i, component, err, "must start with pchar")
+		case isPChar(c), c == '/':
+		case c == '?' && query:
+		default:
+			return p.fail(i, component, err, "illegal character %q", c)
+		}
+	}
+
+	return nil
+}
+
+func isAlphaNum(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
+
+func isHex(c byte) bool {
+	return ('0' <= c && c <= '9') || ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')
+}
+
+// pchar = unreserved / pct-encoded / sub-delims / ":" / "@"
+func isPChar(c byte) bool {
+	return isAlphaNum(c) || strings.IndexByte("-._~!$&'()*+,;=:@", c) != -1
+}
@@ -0,0 +1,110 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package urn_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/fogfish/curie/v2/urn"
+	"github.com/fogfish/it/v2"
+)
+
+func TestParse(t *testing.T) {
+	for _, id := range []string{
+		"urn:isbn:0451450523",
+		"URN:ISBN:0451450523",
+		"urn:ietf:rfc:2648",
+		"urn:example:a/b/c",
+		"urn:example:%2Fa",
+		"urn:example:a123,z456",
+		"urn:example:a?+r/1?=q?1#f",
+		"urn:example:a?=lang=en#sec",
+		"urn:example:a#",
+		"urn:urn-7:informal",
+		"urn:a-b-c:x",
+		"urn:abcdefghijklmnopqrstuvwxyz012345:x",
+	} {
+		t.Run(fmt.Sprintf("(%s)", id), func(t *testing.T) {
+			v, err := urn.Parse(id)
+
+			it.Then(t).Should(
+				it.Nil(err),
+				it.Equal(v, urn.URN(id)),
+			)
+		})
+	}
+}
+
+func TestParseFail(t *testing.T) {
+	for id, expect := range map[string]struct {
+		err       error
+		component string
+		offset    int
+	}{
+		"":           {urn.ErrInvalidScheme, "scheme", 0},
+		"uri:isbn:1": {urn.ErrInvalidScheme, "scheme", 0},
+		"urn:a:b":    {urn.ErrInvalidNID, "NID", 4},
+		"urn:-bad-:": {urn.ErrInvalidNID, "NID", 4},
+		"urn:bad-:x": {urn.ErrInvalidNID, "NID", 7},
+		"urn:a_b:x":  {urn.ErrInvalidNID, "NID", 5},
+		"urn:abcdefghijklmnopqrstuvwxyz0123456:x": {urn.ErrInvalidNID, "NID", 4},
+		"urn:urn-x:a":   {urn.ErrReservedNID, "NID", 8},
+		"urn:urn-01:a":  {urn.ErrReservedNID, "NID", 8},
+		"urn:X-abc:a":   {urn.ErrReservedNID, "NID", 4},
+		"urn:isbn":      {urn.ErrInvalidNSS, "NSS", 8},
+		"urn:isbn:":     {urn.ErrInvalidNSS, "NSS", 9},
+		"urn:isbn:/a":   {urn.ErrInvalidNSS, "NSS", 9},
+		"urn:isbn:a b":  {urn.ErrInvalidNSS, "NSS", 10},
+		"urn:isbn:a%2":  {urn.ErrInvalidNSS, "NSS", 10},
+		"urn:isbn:a%zz": {urn.ErrInvalidNSS, "NSS", 10},
+		"urn:isbn:a?b":  {urn.ErrInvalidNSS, "NSS", 10},
+		"urn:isbn:a?+":  {urn.ErrInvalidComponent, "r-component", 12},
+		"urn:isbn:a?=^": {urn.ErrInvalidComponent, "q-component", 12},
+		"urn:isbn:a#^":  {urn.ErrInvalidComponent, "f-component", 11},
+	} {
+		t.Run(fmt.Sprintf("(%s)", id), func(t *testing.T) {
+			_, err := urn.Parse(id)
+
+			var e *urn.SyntaxError
+			it.Then(t).Should(
+				it.True(errors.Is(err, expect.err)),
+				it.True(errors.As(err, &e)),
+				it.Equal(e.Component, expect.component),
+				it.Equal(e.Offset, expect.offset),
+			)
+		})
+	}
+}
+
+func TestCodecStrict(t *testing.T) {
+	type Struct struct {
+		ID urn.URN `json:"id"`
+	}
+
+	for _, id := range []urn.URN{
+		"urn:a",
+		"urn:-bad-:",
+		"urn:isbn:a b",
+	} {
+		t.Run(fmt.Sprintf("(%s)", id), func(t *testing.T) {
+			var recv Struct
+
+			_, err1 := json.Marshal(Struct{ID: id})
+			err2 := json.Unmarshal([]byte("{\"id\":\""+string(id)+"\"}"), &recv)
+
+			it.Then(t).Should(
+				it.True(errors.Is(err1, urn.ErrInvalidNID) || errors.Is(err1, urn.ErrInvalidNSS)),
+				it.True(errors.Is(err2, urn.ErrInvalidNID) || errors.Is(err2, urn.ErrInvalidNSS)),
+			)
+		})
+	}
+}
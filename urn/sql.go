@@ -0,0 +1,71 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package urn
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Value implements driver.Valuer, URN is validated before it is stored
+func (urn URN) Value() (driver.Value, error) {
+	if err := validate(string(urn)); err != nil {
+		return nil, err
+	}
+
+	return string(urn), nil
+}
+
+// Scan implements sql.Scanner, NULL is scanned as Empty
+func (urn *URN) Scan(src any) error {
+	var val string
+	switch v := src.(type) {
+	case nil:
+	case string:
+		val = v
+	case []byte:
+		val = string(v)
+	default:
+		return fmt.Errorf("urn: cannot scan %T into URN", src)
+	}
+
+	if err := validate(val); err != nil {
+		return err
+	}
+
+	*urn = URN(val)
+	return nil
+}
+
+// NullURN is URN that may be NULL, it distinguishes NULL from Empty
+type NullURN struct {
+	URN   URN
+	Valid bool // Valid is true if URN is not NULL
+}
+
+// Value implements driver.Valuer
+func (n NullURN) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	return n.URN.Value()
+}
+
+// Scan implements sql.Scanner
+func (n *NullURN) Scan(src any) error {
+	if src == nil {
+		n.URN, n.Valid = Empty, false
+		return nil
+	}
+
+	err := n.URN.Scan(src)
+	n.Valid = err == nil
+	return err
+}
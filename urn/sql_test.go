@@ -0,0 +1,53 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package urn_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fogfish/curie/v2/urn"
+	"github.com/fogfish/it/v2"
+)
+
+func TestSQL(t *testing.T) {
+	t.Run("URN", func(t *testing.T) {
+		var id urn.URN
+		val, err := urn.URN("urn:isbn:0451450523").Value()
+		_, errv := urn.URN("isbn:0451450523").Value()
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(val.(string), "urn:isbn:0451450523"),
+			it.True(errors.Is(errv, urn.ErrInvalidScheme)),
+			it.Nil(id.Scan([]byte("urn:isbn:0451450523"))),
+			it.Equal(id, "urn:isbn:0451450523"),
+			it.True(errors.Is(id.Scan("urn:x-y:z"), urn.ErrReservedNID)),
+			it.Nil(id.Scan(nil)),
+			it.Equal(id, urn.Empty),
+		)
+	})
+
+	t.Run("NullURN", func(t *testing.T) {
+		var null, empty, invalid urn.NullURN
+		val, err := urn.NullURN{}.Value()
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Nil(val),
+			it.Nil(null.Scan(nil)),
+			it.True(!null.Valid),
+			it.Nil(empty.Scan("")),
+			it.True(empty.Valid),
+			it.Equal(empty.URN, urn.Empty),
+			it.True(invalid.Scan("isbn:0451450523") != nil),
+			it.True(!invalid.Valid),
+		)
+	})
+}
@@ -12,7 +12,6 @@ package urn
 
 import (
 	"encoding/json"
-	"fmt"
 	"path/filepath"
 	"strings"
 
@@ -24,10 +23,13 @@ import (
 // "urn" URI scheme and a particular URN namespace, with the intent that
 // the URN will be a persistent, location-independent resource identifier.
 //
-//	namestring   = "urn" ":" NID ":" NSS
+//	namestring   = "urn" ":" NID ":" NSS [ rq-components ] [ "#" f-component ]
 //	NID          = (alphanum) 0*30(ldh) (alphanum)
 //	ldh          = alphanum / "-"
 //	NSS          = pchar *(pchar / "/" / ":")
+//
+// The hierarchy operations (Head, Tail, Join, Cut, ...) act on NSS only,
+// r-, q- and f- components are preserved, see Components.
 type URN string
 
 // Empty URN
@@ -47,11 +49,11 @@ func New(schema, ref string) URN {
 
 // MarshalJSON `URN ⟼ "urn:schema:reference"`
 func (urn URN) MarshalJSON() ([]byte, error) {
-	if len(urn) == 0 || (len(urn) > 5 && strings.HasPrefix(string(urn), "urn:")) {
-		return json.Marshal(string(urn))
+	if err := validate(string(urn)); err != nil {
+		return nil, err
 	}
 
-	return nil, fmt.Errorf("invalid URN %s", urn)
+	return json.Marshal(string(urn))
 }
 
 // UnmarshalJSON `"urn:schema:reference" ⟼ URN`
@@ -62,12 +64,12 @@ func (urn *URN) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
-	if len(val) == 0 || (len(val) > 5 && strings.HasPrefix(val, "urn:")) {
-		*urn = URN(val)
-		return nil
+	if err := validate(val); err != nil {
+		return err
 	}
 
-	return fmt.Errorf("invalid URN %s", val)
+	*urn = URN(val)
+	return nil
 }
 
 // Return URN Schema
@@ -93,18 +95,8 @@ func (urn URN) Split() (string, string) { return Split(urn) }
 
 // Split URN into NID and NSS
 func Split(urn URN) (string, string) {
-	if len(urn) < 5 {
-		return "", ""
-	}
-
-	s := urn[4:]
-	n := strings.IndexRune(string(s), ':')
-
-	if n == -1 {
-		return string(s), ""
-	}
-
-	return string(s[:n]), string(s[n+1:])
+	c := Decompose(urn)
+	return c.NID, c.NSS
 }
 
 // Base returns the last element of CURIE reference
@@ -125,7 +117,7 @@ func Base(iri URN) string {
 
 // Path returns all but the last element of CURIE reference
 func Path(iri URN) URN {
-	schema, ref := Split(iri)
+	_, ref := Split(iri)
 	if len(ref) == 0 {
 		return iri
 	}
@@ -137,7 +129,7 @@ func Path(iri URN) URN {
 		ref = ref[:n]
 	}
 
-	return New(schema, ref)
+	return withNSS(iri, ref)
 }
 
 // Head returns the head element of CURIE reference
@@ -158,7 +150,7 @@ func Head(iri URN) string {
 
 // Path returns all but the fiirst element of CURIE reference
 func Tail(iri URN) URN {
-	schema, ref := Split(iri)
+	_, ref := Split(iri)
 	if len(ref) == 0 {
 		return iri
 	}
@@ -170,7 +162,7 @@ func Tail(iri URN) URN {
 		ref = ref[n+1:]
 	}
 
-	return New(schema, ref)
+	return withNSS(iri, ref)
 }
 
 // Join composes segments into new descendant URN.
@@ -180,8 +172,8 @@ func (urn URN) Join(segments ...string) URN { return Join(urn, segments...) }
 //
 // urn:a:b:c × [d, e, f] ⟼ a:b:c:d:e:f
 func Join(urn URN, segments ...string) URN {
-	schema, ref := Split(urn)
-	return New(schema, reference.Join(ref, ':', segments...))
+	_, ref := Split(urn)
+	return withNSS(urn, reference.Join(ref, ':', segments...))
 }
 
 // Cut N components from URN NSS
@@ -189,8 +181,8 @@ func (urn URN) Cut(n int) URN { return Cut(urn, n) }
 
 // Cut N components from URN NSS
 func Cut(urn URN, n int) URN {
-	schema, ref := Split(urn)
-	return New(schema, reference.Split(ref, ':', n))
+	_, ref := Split(urn)
+	return withNSS(urn, reference.Split(ref, ':', n))
 }
 
 // Conver URN to IRI
@@ -0,0 +1,153 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package vocab
+
+import "github.com/fogfish/curie/v2"
+
+// RDF terms
+const (
+	RDFType       = curie.IRI("rdf:type")
+	RDFProperty   = curie.IRI("rdf:Property")
+	RDFStatement  = curie.IRI("rdf:Statement")
+	RDFSubject    = curie.IRI("rdf:subject")
+	RDFPredicate  = curie.IRI("rdf:predicate")
+	RDFObject     = curie.IRI("rdf:object")
+	RDFValue      = curie.IRI("rdf:value")
+	RDFList       = curie.IRI("rdf:List")
+	RDFFirst      = curie.IRI("rdf:first")
+	RDFRest       = curie.IRI("rdf:rest")
+	RDFNil        = curie.IRI("rdf:nil")
+	RDFLangString = curie.IRI("rdf:langString")
+)
+
+// RDFS terms
+const (
+	RDFSResource      = curie.IRI("rdfs:Resource")
+	RDFSClass         = curie.IRI("rdfs:Class")
+	RDFSLiteral       = curie.IRI("rdfs:Literal")
+	RDFSDatatype      = curie.IRI("rdfs:Datatype")
+	RDFSLabel         = curie.IRI("rdfs:label")
+	RDFSComment       = curie.IRI("rdfs:comment")
+	RDFSSubClassOf    = curie.IRI("rdfs:subClassOf")
+	RDFSSubPropertyOf = curie.IRI("rdfs:subPropertyOf")
+	RDFSDomain        = curie.IRI("rdfs:domain")
+	RDFSRange         = curie.IRI("rdfs:range")
+	RDFSSeeAlso       = curie.IRI("rdfs:seeAlso")
+	RDFSIsDefinedBy   = curie.IRI("rdfs:isDefinedBy")
+)
+
+// OWL terms
+const (
+	OWLThing              = curie.IRI("owl:Thing")
+	OWLNothing            = curie.IRI("owl:Nothing")
+	OWLClass              = curie.IRI("owl:Class")
+	OWLObjectProperty     = curie.IRI("owl:ObjectProperty")
+	OWLDatatypeProperty   = curie.IRI("owl:DatatypeProperty")
+	OWLAnnotationProperty = curie.IRI("owl:AnnotationProperty")
+	OWLOntology           = curie.IRI("owl:Ontology")
+	OWLSameAs             = curie.IRI("owl:sameAs")
+	OWLEquivalentClass    = curie.IRI("owl:equivalentClass")
+	OWLInverseOf          = curie.IRI("owl:inverseOf")
+)
+
+// XSD datatypes
+const (
+	XSDString   = curie.IRI("xsd:string")
+	XSDBoolean  = curie.IRI("xsd:boolean")
+	XSDDecimal  = curie.IRI("xsd:decimal")
+	XSDInteger  = curie.IRI("xsd:integer")
+	XSDLong     = curie.IRI("xsd:long")
+	XSDInt      = curie.IRI("xsd:int")
+	XSDDouble   = curie.IRI("xsd:double")
+	XSDFloat    = curie.IRI("xsd:float")
+	XSDDate     = curie.IRI("xsd:date")
+	XSDDateTime = curie.IRI("xsd:dateTime")
+	XSDDuration = curie.IRI("xsd:duration")
+	XSDAnyURI   = curie.IRI("xsd:anyURI")
+)
+
+// SKOS terms
+const (
+	SKOSConcept       = curie.IRI("skos:Concept")
+	SKOSConceptScheme = curie.IRI("skos:ConceptScheme")
+	SKOSPrefLabel     = curie.IRI("skos:prefLabel")
+	SKOSAltLabel      = curie.IRI("skos:altLabel")
+	SKOSDefinition    = curie.IRI("skos:definition")
+	SKOSNotation      = curie.IRI("skos:notation")
+	SKOSBroader       = curie.IRI("skos:broader")
+	SKOSNarrower      = curie.IRI("skos:narrower")
+	SKOSRelated       = curie.IRI("skos:related")
+	SKOSInScheme      = curie.IRI("skos:inScheme")
+	SKOSExactMatch    = curie.IRI("skos:exactMatch")
+)
+
+// Dublin Core terms
+const (
+	DCTermsTitle       = curie.IRI("dcterms:title")
+	DCTermsDescription = curie.IRI("dcterms:description")
+	DCTermsCreator     = curie.IRI("dcterms:creator")
+	DCTermsCreated     = curie.IRI("dcterms:created")
+	DCTermsModified    = curie.IRI("dcterms:modified")
+	DCTermsIdentifier  = curie.IRI("dcterms:identifier")
+	DCTermsLicense     = curie.IRI("dcterms:license")
+	DCTermsPublisher   = curie.IRI("dcterms:publisher")
+	DCTermsSubject     = curie.IRI("dcterms:subject")
+)
+
+// FOAF terms
+const (
+	FOAFPerson       = curie.IRI("foaf:Person")
+	FOAFAgent        = curie.IRI("foaf:Agent")
+	FOAFOrganization = curie.IRI("foaf:Organization")
+	FOAFDocument     = curie.IRI("foaf:Document")
+	FOAFName         = curie.IRI("foaf:name")
+	FOAFMbox         = curie.IRI("foaf:mbox")
+	FOAFHomepage     = curie.IRI("foaf:homepage")
+	FOAFKnows        = curie.IRI("foaf:knows")
+	FOAFDepiction    = curie.IRI("foaf:depiction")
+)
+
+// Schema.org terms
+const (
+	SchemaThing        = curie.IRI("schema:Thing")
+	SchemaPerson       = curie.IRI("schema:Person")
+	SchemaOrganization = curie.IRI("schema:Organization")
+	SchemaPlace        = curie.IRI("schema:Place")
+	SchemaCreativeWork = curie.IRI("schema:CreativeWork")
+	SchemaName         = curie.IRI("schema:name")
+	SchemaDescription  = curie.IRI("schema:description")
+	SchemaURL          = curie.IRI("schema:url")
+	SchemaIdentifier   = curie.IRI("schema:identifier")
+	SchemaSameAs       = curie.IRI("schema:sameAs")
+)
+
+// PROV terms
+const (
+	PROVEntity            = curie.IRI("prov:Entity")
+	PROVActivity          = curie.IRI("prov:Activity")
+	PROVAgent             = curie.IRI("prov:Agent")
+	PROVWasGeneratedBy    = curie.IRI("prov:wasGeneratedBy")
+	PROVWasDerivedFrom    = curie.IRI("prov:wasDerivedFrom")
+	PROVWasAttributedTo   = curie.IRI("prov:wasAttributedTo")
+	PROVUsed              = curie.IRI("prov:used")
+	PROVGeneratedAtTime   = curie.IRI("prov:generatedAtTime")
+	PROVWasAssociatedWith = curie.IRI("prov:wasAssociatedWith")
+)
+
+// DCAT terms
+const (
+	DCATCatalog      = curie.IRI("dcat:Catalog")
+	DCATDataset      = curie.IRI("dcat:Dataset")
+	DCATDistribution = curie.IRI("dcat:Distribution")
+	DCATDataService  = curie.IRI("dcat:DataService")
+	DCATAccessURL    = curie.IRI("dcat:accessURL")
+	DCATDownloadURL  = curie.IRI("dcat:downloadURL")
+	DCATMediaType    = curie.IRI("dcat:mediaType")
+	DCATKeyword      = curie.IRI("dcat:keyword")
+)
@@ -0,0 +1,63 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+// Package vocab defines well-known namespaces of linked-data vocabularies
+// and terms for commonly used classes and properties.
+//
+//	vocab.RDF.IRI("type") ~ vocab.RDFType
+//	curie.URI(vocab.Namespaces(), vocab.RDFType) ⟼ http://www.w3.org/1999/02/22-rdf-syntax-ns#type
+package vocab
+
+import "github.com/fogfish/curie/v2"
+
+// Well-known namespaces
+const (
+	RDF     = curie.Namespace("rdf")
+	RDFS    = curie.Namespace("rdfs")
+	OWL     = curie.Namespace("owl")
+	XSD     = curie.Namespace("xsd")
+	SKOS    = curie.Namespace("skos")
+	DC      = curie.Namespace("dc")
+	DCTerms = curie.Namespace("dcterms")
+	FOAF    = curie.Namespace("foaf")
+	Schema  = curie.Namespace("schema")
+	PROV    = curie.Namespace("prov")
+	DCAT    = curie.Namespace("dcat")
+	SH      = curie.Namespace("sh")
+	VOID    = curie.Namespace("void")
+	VCard   = curie.Namespace("vcard")
+	Time    = curie.Namespace("time")
+)
+
+// Namespaces returns the prefix table of well-known namespaces, the table is
+// the copy owned by the caller.
+func Namespaces() curie.Namespaces {
+	ns := make(curie.Namespaces, len(namespaces))
+	for prefix, iri := range namespaces {
+		ns[prefix] = iri
+	}
+	return ns
+}
+
+var namespaces = map[string]string{
+	string(RDF):     "http://www.w3.org/1999/02/22-rdf-syntax-ns#",
+	string(RDFS):    "http://www.w3.org/2000/01/rdf-schema#",
+	string(OWL):     "http://www.w3.org/2002/07/owl#",
+	string(XSD):     "http://www.w3.org/2001/XMLSchema#",
+	string(SKOS):    "http://www.w3.org/2004/02/skos/core#",
+	string(DC):      "http://purl.org/dc/elements/1.1/",
+	string(DCTerms): "http://purl.org/dc/terms/",
+	string(FOAF):    "http://xmlns.com/foaf/0.1/",
+	string(Schema):  "https://schema.org/",
+	string(PROV):    "http://www.w3.org/ns/prov#",
+	string(DCAT):    "http://www.w3.org/ns/dcat#",
+	string(SH):      "http://www.w3.org/ns/shacl#",
+	string(VOID):    "http://rdfs.org/ns/void#",
+	string(VCard):   "http://www.w3.org/2006/vcard/ns#",
+	string(Time):    "http://www.w3.org/2006/time#",
+}
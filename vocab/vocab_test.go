@@ -0,0 +1,53 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package vocab_test
+
+import (
+	"testing"
+
+	"github.com/fogfish/curie/v2"
+	"github.com/fogfish/curie/v2/vocab"
+	"github.com/fogfish/it/v2"
+)
+
+func TestNamespaces(t *testing.T) {
+	for _, ns := range []curie.Namespace{
+		vocab.RDF, vocab.RDFS, vocab.OWL, vocab.XSD, vocab.SKOS,
+		vocab.DC, vocab.DCTerms, vocab.FOAF, vocab.Schema, vocab.PROV,
+		vocab.DCAT, vocab.SH, vocab.VOID, vocab.VCard, vocab.Time,
+	} {
+		t.Run(string(ns), func(t *testing.T) {
+			prefix, has := vocab.Namespaces().Lookup(string(ns))
+
+			it.Then(t).Should(
+				it.True(has),
+				it.Equal(curie.URI(vocab.Namespaces(), ns.IRI("x")), prefix+"x"),
+				it.Equal(curie.FromURI(vocab.Namespaces(), prefix+"x"), ns.IRI("x")),
+			)
+		})
+	}
+}
+
+func TestTerms(t *testing.T) {
+	it.Then(t).Should(
+		it.Equal(vocab.RDF.IRI("type"), vocab.RDFType),
+		it.Equal(curie.URI(vocab.Namespaces(), vocab.RDFType), "http://www.w3.org/1999/02/22-rdf-syntax-ns#type"),
+		it.Equal(curie.URI(vocab.Namespaces(), vocab.SchemaPerson), "https://schema.org/Person"),
+		it.Equal(curie.FromURI(vocab.Namespaces(), "http://xmlns.com/foaf/0.1/knows"), vocab.FOAFKnows),
+	)
+}
+
+func TestNamespacesCopy(t *testing.T) {
+	ns := vocab.Namespaces()
+	ns[string(vocab.RDF)] = "https://example.com/"
+
+	it.Then(t).Should(
+		it.Equal(curie.URI(vocab.Namespaces(), vocab.RDFType), "http://www.w3.org/1999/02/22-rdf-syntax-ns#type"),
+	)
+}
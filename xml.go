@@ -0,0 +1,507 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie
+
+import (
+	"encoding/xml"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// XMLName converts CURIE to XML name, the namespace is expansion of the prefix.
+// The prefix is kept as the namespace if it is not defined, similarly to
+// encoding/xml.
+//
+//	foaf:Person ⟼ xml.Name{Space: "http://xmlns.com/foaf/0.1/", Local: "Person"}
+func XMLName(prefixes Prefixes, iri IRI) xml.Name {
+	schema, ref := Split(iri)
+	if schema == BlankNode {
+		return xml.Name{Space: schema, Local: ref}
+	}
+
+	if ns, exists := prefixes.Lookup(schema); exists {
+		return xml.Name{Space: ns, Local: ref}
+	}
+
+	return xml.Name{Space: schema, Local: ref}
+}
+
+// FromXMLName converts XML name to CURIE, the prefix bound to the namespace is
+// used if it exists, otherwise the name is compacted as URI. The namespace
+// which is NCName is the undefined prefix.
+//
+//	xml.Name{Space: "http://xmlns.com/foaf/0.1/", Local: "Person"} ⟼ foaf:Person
+func FromXMLName(prefixes Prefixes, name xml.Name) IRI {
+	if len(name.Space) == 0 {
+		return IRI(name.Local)
+	}
+
+	// encoding/xml keeps undefined prefix as the namespace
+	if isNCName(name.Space) {
+		return New(name.Space, name.Local)
+	}
+
+	if iri := prefixes.Create(name.Space); len(Reference(iri)) == 0 && strings.IndexByte(string(iri), ':') > 0 {
+		return New(Schema(iri), name.Local)
+	}
+
+	return FromURI(prefixes, name.Space+name.Local)
+}
+
+// XMLNS returns `xmlns:` declarations of prefixes used by CURIEs, sorted by
+// prefix. The DefaultPrefix is declared as default namespace, undefined
+// prefixes are skipped. Use it to declare namespaces of attribute values.
+//
+//	start.Attr = append(start.Attr, curie.XMLNS(prefixes, iri)...)
+func XMLNS(prefixes Prefixes, iris ...IRI) []xml.Attr {
+	seen := map[string]struct{}{}
+	attrs := make([]xml.Attr, 0)
+	for _, iri := range iris {
+		if len(iri) == 0 {
+			continue
+		}
+
+		schema := Schema(iri)
+		if _, has := seen[schema]; has || schema == BlankNode {
+			continue
+		}
+		seen[schema] = struct{}{}
+
+		ns, exists := prefixes.Lookup(schema)
+		if !exists {
+			continue
+		}
+
+		name := xml.Name{Local: "xmlns"}
+		if schema != DefaultPrefix {
+			name.Local = "xmlns:" + schema
+		}
+		attrs = append(attrs, xml.Attr{Name: name, Value: ns})
+	}
+
+	sort.Slice(attrs, func(i, j int) bool { return attrs[i].Name.Local < attrs[j].Name.Local })
+	return attrs
+}
+
+// MarshalXMLAttr `IRI ⟼ prefix:suffix`, empty IRI is omitted.
+//
+// The attribute marshaler cannot declare namespaces, encoding/xml does not
+// allow it. Use XMLEncoder to declare prefixes of attribute values or XMLNS
+// with `,any,attr` field.
+func (iri IRI) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if len(iri) == 0 {
+		return xml.Attr{}, nil
+	}
+
+	return xml.Attr{Name: name, Value: string(iri)}, nil
+}
+
+// UnmarshalXMLAttr `prefix:suffix ⟼ IRI`, safe CURIE is accepted.
+// Use XMLDecoder to resolve prefixes from in-scope `xmlns:` declarations.
+func (iri *IRI) UnmarshalXMLAttr(attr xml.Attr) error {
+	val := attr.Value
+	if len(val) > 1 && val[0] == '[' && val[len(val)-1] == ']' {
+		val = val[1 : len(val)-1]
+	}
+
+	*iri = IRI(val)
+	return nil
+}
+
+// XMLDecoder resolves QName values of IRI attributes using in-scope `xmlns:`
+// declarations of the document. The value is replaced with CURIE of the
+// application if prefixes compacts its namespace, so that documents might use
+// own prefixes.
+//
+//	<a xmlns:f="http://xmlns.com/foaf/0.1/" type="f:Person"/>
+//	type="f:Person" ⟼ foaf:Person
+//
+// IRI attributes are discovered from the type of decoded value by their names.
+// Values of other attributes are not changed. The attribute is not resolved
+// if its name is used by IRI and other type of attribute within the value.
+type XMLDecoder struct {
+	*xml.Decoder
+	scope *xmlScope
+}
+
+// NewXMLDecoder creates decoder that resolves IRI attributes using in-scope
+// `xmlns:` declarations of the document.
+func NewXMLDecoder(r io.Reader, prefixes Prefixes) *XMLDecoder {
+	scope := &xmlScope{d: xml.NewDecoder(r), prefixes: prefixes}
+	return &XMLDecoder{Decoder: xml.NewTokenDecoder(scope), scope: scope}
+}
+
+// Decode works like xml.Decoder.Decode
+func (d *XMLDecoder) Decode(v any) error { return d.DecodeElement(v, nil) }
+
+// DecodeElement works like xml.Decoder.DecodeElement
+func (d *XMLDecoder) DecodeElement(v any, start *xml.StartElement) error {
+	d.scope.attrs = xmlAttrsOf(reflect.TypeOf(v))
+	defer func() { d.scope.attrs = nil }()
+
+	if start != nil {
+		s := start.Copy()
+		d.scope.resolveAttrs(s.Attr)
+		start = &s
+	}
+
+	return d.Decoder.DecodeElement(v, start)
+}
+
+// xmlScope tracks in-scope namespace declarations
+type xmlScope struct {
+	d        *xml.Decoder
+	prefixes Prefixes
+	scopes   []map[string]string
+	attrs    map[string]bool
+}
+
+func (s *xmlScope) Token() (xml.Token, error) {
+	t, err := s.d.Token()
+	if err != nil {
+		return t, err
+	}
+
+	switch e := t.(type) {
+	case xml.StartElement:
+		scope := map[string]string{}
+		for _, attr := range e.Attr {
+			switch {
+			case attr.Name.Space == "xmlns":
+				scope[attr.Name.Local] = attr.Value
+			case attr.Name.Space == "" && attr.Name.Local == "xmlns":
+				scope[DefaultPrefix] = attr.Value
+			}
+		}
+		s.scopes = append(s.scopes, scope)
+
+		if len(s.attrs) != 0 {
+			e = e.Copy()
+			s.resolveAttrs(e.Attr)
+		}
+		return e, nil
+	case xml.EndElement:
+		if len(s.scopes) > 0 {
+			s.scopes = s.scopes[:len(s.scopes)-1]
+		}
+	}
+
+	return t, nil
+}
+
+// resolve values of IRI attributes
+func (s *xmlScope) resolveAttrs(attrs []xml.Attr) {
+	for i, attr := range attrs {
+		if s.attrs[attr.Name.Local] && attr.Name.Space != "xmlns" {
+			attrs[i].Value = s.resolve(attr.Value)
+		}
+	}
+}
+
+// resolve QName using in-scope namespaces
+func (s *xmlScope) resolve(val string) string {
+	val = unbracket(val)
+
+	n := strings.IndexByte(val, ':')
+	if n <= 0 || !isNCName(val[:n]) {
+		return val
+	}
+
+	ns, exists := s.lookup(val[:n])
+	if !exists {
+		return val
+	}
+
+	uri := ns + val[n+1:]
+	if iri := FromURI(s.prefixes, uri); string(iri) != uri {
+		return string(iri)
+	}
+
+	return val
+}
+
+func (s *xmlScope) lookup(prefix string) (string, bool) {
+	for i := len(s.scopes) - 1; i >= 0; i-- {
+		if ns, exists := s.scopes[i][prefix]; exists {
+			return ns, true
+		}
+	}
+	return "", false
+}
+
+// XMLEncoder declares prefixes of IRI attributes. The `xmlns:` declarations
+// of prefixes used by values of IRI attributes are added to the start element,
+// so that the document is decodable with XMLDecoder.
+//
+//	<person xmlns:foaf="http://xmlns.com/foaf/0.1/" type="foaf:Person"></person>
+//
+// IRI attributes are discovered from the type of encoded value by their names,
+// same as XMLDecoder does. The DefaultPrefix is not declared, it would change
+// the namespace of elements.
+type XMLEncoder struct {
+	*xml.Encoder
+	prefixes Prefixes
+}
+
+// NewXMLEncoder creates encoder that declares prefixes of IRI attributes.
+func NewXMLEncoder(w io.Writer, prefixes Prefixes) *XMLEncoder {
+	return &XMLEncoder{Encoder: xml.NewEncoder(w), prefixes: prefixes}
+}
+
+// Encode works like xml.Encoder.Encode
+func (e *XMLEncoder) Encode(v any) error {
+	val := reflect.ValueOf(v)
+	if val.Kind() == reflect.Slice && val.Type().Elem().Kind() != reflect.Uint8 || val.Kind() == reflect.Array {
+		for i := 0; i < val.Len(); i++ {
+			if err := e.Encode(val.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if _, ok := v.(xml.Marshaler); ok {
+		return e.Encoder.Encode(v)
+	}
+
+	attrs := e.xmlns(val, nil)
+	if len(attrs) == 0 {
+		return e.Encoder.Encode(v)
+	}
+
+	start, ok := xmlStartOf(val)
+	if !ok {
+		return e.Encoder.Encode(v)
+	}
+	start.Attr = attrs
+
+	return e.Encoder.EncodeElement(v, start)
+}
+
+// EncodeElement works like xml.Encoder.EncodeElement
+func (e *XMLEncoder) EncodeElement(v any, start xml.StartElement) error {
+	start = start.Copy()
+	start.Attr = append(e.xmlns(reflect.ValueOf(v), start.Attr), start.Attr...)
+
+	return e.Encoder.EncodeElement(v, start)
+}
+
+// declarations of prefixes used by IRI attributes, except declared ones
+func (e *XMLEncoder) xmlns(val reflect.Value, declared []xml.Attr) []xml.Attr {
+	if !val.IsValid() {
+		return nil
+	}
+
+	attrs := xmlAttrsOf(val.Type())
+	if len(attrs) == 0 {
+		return nil
+	}
+
+	iris := []IRI{}
+	xmlIRIsWalk(val, attrs, &iris, map[uintptr]bool{})
+
+	seq := make([]xml.Attr, 0, len(iris))
+	for _, attr := range XMLNS(e.prefixes, iris...) {
+		if attr.Name.Local != "xmlns" && !xmlDeclared(declared, attr.Name.Local[len("xmlns:"):]) {
+			seq = append(seq, attr)
+		}
+	}
+	return seq
+}
+
+func xmlDeclared(attrs []xml.Attr, prefix string) bool {
+	for _, attr := range attrs {
+		if (attr.Name.Space == "xmlns" && attr.Name.Local == prefix) || attr.Name.Local == "xmlns:"+prefix {
+			return true
+		}
+	}
+	return false
+}
+
+var typeOfXMLName = reflect.TypeOf(xml.Name{})
+
+// start element of the struct, similarly to encoding/xml the name is either
+// XMLName field or the type name
+func xmlStartOf(val reflect.Value) (xml.StartElement, bool) {
+	for val.Kind() == reflect.Pointer || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return xml.StartElement{}, false
+		}
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Struct {
+		return xml.StartElement{}, false
+	}
+
+	if f, has := val.Type().FieldByName("XMLName"); has && f.Type == typeOfXMLName {
+		name, _, _ := strings.Cut(f.Tag.Get("xml"), ",")
+		if len(name) != 0 {
+			space, local, has := strings.Cut(name, " ")
+			if !has {
+				space, local = "", name
+			}
+			return xml.StartElement{Name: xml.Name{Space: space, Local: local}}, true
+		}
+
+		if fv, err := val.FieldByIndexErr(f.Index); err == nil {
+			name := xml.Name{Space: fv.FieldByName("Space").String(), Local: fv.FieldByName("Local").String()}
+			if len(name.Local) != 0 {
+				return xml.StartElement{Name: name}, true
+			}
+		}
+	}
+
+	name := val.Type().Name()
+	if n := strings.IndexByte(name, '['); n != -1 {
+		name = name[:n]
+	}
+
+	return xml.StartElement{Name: xml.Name{Local: name}}, len(name) != 0
+}
+
+//------------------------------------------------------------------------------
+
+var typeOfIRI = reflect.TypeOf(IRI(""))
+
+// names of IRI attributes used by the type, the name is excluded if it is
+// also used by attribute of other type
+func xmlAttrsOf(t reflect.Type) map[string]bool {
+	attrs := map[string]bool{}
+	xmlAttrsWalk(t, attrs, map[reflect.Type]bool{})
+
+	for name, isIRI := range attrs {
+		if !isIRI {
+			delete(attrs, name)
+		}
+	}
+	return attrs
+}
+
+func xmlAttrsWalk(t reflect.Type, attrs map[string]bool, seen map[reflect.Type]bool) {
+	for t != nil && (t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct || seen[t] {
+		return
+	}
+	seen[t] = true
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, isAttr, ok := xmlFieldOf(f)
+		switch {
+		case !ok:
+			continue
+		case !isAttr:
+			xmlAttrsWalk(f.Type, attrs, seen)
+			continue
+		}
+
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+
+		isIRI := ft == typeOfIRI
+		if was, has := attrs[name]; has {
+			isIRI = isIRI && was
+		}
+		attrs[name] = isIRI
+	}
+}
+
+// values of IRI attributes used by the value
+func xmlIRIsWalk(v reflect.Value, attrs map[string]bool, iris *[]IRI, seen map[uintptr]bool) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		if v.Kind() == reflect.Pointer {
+			if seen[v.Pointer()] {
+				return
+			}
+			seen[v.Pointer()] = true
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			xmlIRIsWalk(v.Index(i), attrs, iris, seen)
+		}
+		return
+	case reflect.Struct:
+	default:
+		return
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name, isAttr, ok := xmlFieldOf(t.Field(i))
+		switch {
+		case !ok:
+			continue
+		case !isAttr:
+			xmlIRIsWalk(v.Field(i), attrs, iris, seen)
+			continue
+		}
+
+		fv := v.Field(i)
+		if fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		}
+
+		if attrs[name] && fv.Type() == typeOfIRI {
+			*iris = append(*iris, IRI(fv.String()))
+		}
+	}
+}
+
+// name of the field and its kind, the field is not ok if it is not encoded
+// by encoding/xml or it is `,any,attr`
+func xmlFieldOf(f reflect.StructField) (name string, isAttr bool, ok bool) {
+	if !f.IsExported() && !f.Anonymous {
+		return "", false, false
+	}
+
+	tag := f.Tag.Get("xml")
+	if tag == "-" {
+		return "", false, false
+	}
+
+	name, flags, _ := strings.Cut(tag, ",")
+	if n := strings.LastIndexByte(name, ' '); n != -1 {
+		name = name[n+1:]
+	}
+
+	if !strings.Contains(","+flags+",", ",attr,") {
+		return name, false, true
+	}
+
+	if strings.Contains(","+flags+",", ",any,") {
+		return "", false, false
+	}
+
+	if len(name) == 0 {
+		name = f.Name
+	}
+
+	return name, true, true
+}
@@ -0,0 +1,223 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/curie
+//
+
+package curie_test
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/fogfish/curie/v2"
+	"github.com/fogfish/it/v2"
+)
+
+var xmlPrefixes = curie.Namespaces{
+	"foaf": "http://xmlns.com/foaf/0.1/",
+	"rdf":  "http://www.w3.org/1999/02/22-rdf-syntax-ns#",
+}
+
+func TestXMLName(t *testing.T) {
+	for iri, name := range map[curie.IRI]xml.Name{
+		"foaf:Person": {Space: "http://xmlns.com/foaf/0.1/", Local: "Person"},
+		"rdf:type":    {Space: "http://www.w3.org/1999/02/22-rdf-syntax-ns#", Local: "type"},
+		"ex:Thing":    {Space: "ex", Local: "Thing"},
+		"Thing":       {Local: "Thing"},
+	} {
+		t.Run(string(iri), func(t *testing.T) {
+			it.Then(t).Should(
+				it.Equal(curie.XMLName(xmlPrefixes, iri), name),
+				it.Equal(curie.FromXMLName(xmlPrefixes, name), iri),
+			)
+		})
+	}
+
+	t.Run("Namespace", func(t *testing.T) {
+		iri := curie.FromXMLName(xmlPrefixes, xml.Name{Space: "http://xmlns.com/foaf/0.1/a/", Local: "b"})
+
+		it.Then(t).Should(
+			it.Equal(iri, "foaf:a/b"),
+		)
+	})
+}
+
+type xmlPerson struct {
+	XMLName xml.Name   `xml:"person"`
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Type    curie.IRI  `xml:"type,attr"`
+	Knows   curie.IRI  `xml:"knows,attr,omitempty"`
+	Title   string     `xml:"title,attr,omitempty"`
+}
+
+func TestXMLAttr(t *testing.T) {
+	t.Run("Marshal", func(t *testing.T) {
+		val := xmlPerson{Type: "foaf:Person"}
+		val.Attrs = curie.XMLNS(xmlPrefixes, val.Type, val.Knows, "ex:x", "foaf:Agent")
+		b, err := xml.Marshal(val)
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(string(b), `<person xmlns:foaf="http://xmlns.com/foaf/0.1/" type="foaf:Person"></person>`),
+		)
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		var val xmlPerson
+		err := xml.Unmarshal([]byte(`<person type="[foaf:Person]" knows="foaf:Agent"/>`), &val)
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(val.Type, "foaf:Person"),
+			it.Equal(val.Knows, "foaf:Agent"),
+		)
+	})
+
+	t.Run("InScope", func(t *testing.T) {
+		var val struct {
+			People []xmlPerson `xml:"person"`
+		}
+		err := curie.NewXMLDecoder(strings.NewReader(`
+			<people xmlns:f="http://xmlns.com/foaf/0.1/">
+				<person type="f:Person" knows="x:y"/>
+				<person xmlns:f="http://www.w3.org/1999/02/22-rdf-syntax-ns#" type="f:Property"/>
+				<person type="[f:Agent]" knows="12:30" title="f:hello world"/>
+			</people>
+		`), xmlPrefixes).Decode(&val)
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(len(val.People), 3),
+			it.Equal(val.People[0].Type, "foaf:Person"),
+			it.Equal(val.People[0].Knows, "x:y"),
+			it.Equal(val.People[1].Type, "rdf:Property"),
+			it.Equal(val.People[2].Type, "foaf:Agent"),
+			it.Equal(val.People[2].Knows, "12:30"),
+			it.Equal(val.People[2].Title, "f:hello world"),
+		)
+	})
+
+	t.Run("Ambiguous", func(t *testing.T) {
+		var val struct {
+			Person xmlPerson `xml:"person"`
+			Group  struct {
+				Type string `xml:"type,attr"`
+			} `xml:"group"`
+		}
+		err := curie.NewXMLDecoder(strings.NewReader(`
+			<people xmlns:f="http://xmlns.com/foaf/0.1/">
+				<person type="f:Person" knows="f:Agent"/>
+				<group type="f:Group"/>
+			</people>
+		`), xmlPrefixes).Decode(&val)
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(val.Person.Type, "f:Person"),
+			it.Equal(val.Person.Knows, "foaf:Agent"),
+			it.Equal(val.Group.Type, "f:Group"),
+		)
+	})
+
+	t.Run("DecodeElement", func(t *testing.T) {
+		d := curie.NewXMLDecoder(strings.NewReader(`
+			<people xmlns:f="http://xmlns.com/foaf/0.1/">
+				<person type="f:Person"/>
+			</people>
+		`), xmlPrefixes)
+
+		var val xmlPerson
+		for {
+			tok, err := d.Token()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if start, ok := tok.(xml.StartElement); ok && start.Name.Local == "person" {
+				it.Then(t).Should(
+					it.Nil(d.DecodeElement(&val, &start)),
+					it.Equal(val.Type, "foaf:Person"),
+				)
+				break
+			}
+		}
+	})
+}
+
+func TestXMLNS(t *testing.T) {
+	ns := curie.Namespaces{
+		curie.DefaultPrefix: "https://example.com/",
+		"foaf":              "http://xmlns.com/foaf/0.1/",
+		"rdf":               "http://www.w3.org/1999/02/22-rdf-syntax-ns#",
+	}
+
+	attrs := curie.XMLNS(ns, "rdf:type", "foaf:Person", "Thing", "rdf:Property", "_:b0", "")
+
+	it.Then(t).Should(
+		it.Seq(attrs).Equal(
+			xml.Attr{Name: xml.Name{Local: "xmlns"}, Value: "https://example.com/"},
+			xml.Attr{Name: xml.Name{Local: "xmlns:foaf"}, Value: "http://xmlns.com/foaf/0.1/"},
+			xml.Attr{Name: xml.Name{Local: "xmlns:rdf"}, Value: "http://www.w3.org/1999/02/22-rdf-syntax-ns#"},
+		),
+	)
+}
+
+func TestXMLEncoder(t *testing.T) {
+	t.Run("Encode", func(t *testing.T) {
+		var b strings.Builder
+		err := curie.NewXMLEncoder(&b, xmlPrefixes).Encode(xmlPerson{Type: "foaf:Person", Knows: "rdf:type"})
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(b.String(), `<person xmlns:foaf="http://xmlns.com/foaf/0.1/" xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" type="foaf:Person" knows="rdf:type"></person>`),
+		)
+	})
+
+	t.Run("Nested", func(t *testing.T) {
+		type people struct {
+			People []*xmlPerson `xml:"person"`
+		}
+
+		var b strings.Builder
+		err := curie.NewXMLEncoder(&b, xmlPrefixes).Encode(people{
+			People: []*xmlPerson{{Type: "foaf:Person"}, nil, {Type: "ex:Agent"}},
+		})
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(b.String(), `<people xmlns:foaf="http://xmlns.com/foaf/0.1/"><person type="foaf:Person"></person><person type="ex:Agent"></person></people>`),
+		)
+	})
+
+	t.Run("EncodeElement", func(t *testing.T) {
+		start := xml.StartElement{
+			Name: xml.Name{Local: "agent"},
+			Attr: []xml.Attr{{Name: xml.Name{Local: "xmlns:foaf"}, Value: "http://xmlns.com/foaf/0.1/"}},
+		}
+
+		var b strings.Builder
+		err := curie.NewXMLEncoder(&b, xmlPrefixes).EncodeElement(xmlPerson{Type: "foaf:Agent", Knows: "rdf:type"}, start)
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(b.String(), `<agent xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:foaf="http://xmlns.com/foaf/0.1/" type="foaf:Agent" knows="rdf:type"></agent>`),
+		)
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		var b strings.Builder
+		err := curie.NewXMLEncoder(&b, xmlPrefixes).Encode(xmlPerson{Type: "foaf:Person"})
+		it.Then(t).Should(it.Nil(err))
+
+		var val xmlPerson
+		err = curie.NewXMLDecoder(strings.NewReader(b.String()), curie.Namespaces{"f": "http://xmlns.com/foaf/0.1/"}).Decode(&val)
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(val.Type, "f:Person"),
+		)
+	})
+}